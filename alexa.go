@@ -11,21 +11,20 @@ import (
 )
 
 type Skill struct {
-	db        *sql.DB
-	bible     orthocal.Bible
-	useJulian bool
-	doJump    bool
-	tz        *time.Location
+	db    *sql.DB
+	bible orthocal.Bible
+	tz    *time.Location
+
+	jurisdiction Jurisdiction
 }
 
-func NewSkill(appid string, db *sql.DB, useJulian, doJump bool, bible orthocal.Bible, tz *time.Location) alexa.EchoApplication {
+func NewSkill(appid string, db *sql.DB, jurisdiction Jurisdiction, bible orthocal.Bible, tz *time.Location) alexa.EchoApplication {
 	var skill Skill
 
 	skill.db = db
 	skill.bible = bible
-	skill.useJulian = useJulian
-	skill.doJump = doJump
 	skill.tz = tz
+	skill.jurisdiction = jurisdiction
 
 	return alexa.EchoApplication{
 		AppID:    appid,
@@ -37,7 +36,7 @@ func NewSkill(appid string, db *sql.DB, useJulian, doJump bool, bible orthocal.B
 func (self *Skill) launchHandler(request *alexa.EchoRequest, response *alexa.EchoResponse) {
 	now := time.Now().In(self.tz)
 	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, self.tz)
-	factory := orthocal.NewDayFactory(self.useJulian, self.doJump, self.db)
+	factory := orthocal.NewDayFactory(self.jurisdiction.UseJulian, self.jurisdiction.DoJump, self.db)
 	day := factory.NewDay(today.Year(), int(today.Month()), today.Day(), nil)
 
 	// Create the speech
@@ -57,20 +56,19 @@ func (self *Skill) launchHandler(request *alexa.EchoRequest, response *alexa.Ech
 }
 
 func (self *Skill) intentHandler(request *alexa.EchoRequest, response *alexa.EchoResponse) {
-	var date time.Time
+	factory := orthocal.NewDayFactory(self.jurisdiction.UseJulian, self.jurisdiction.DoJump, self.db)
 
-	factory := orthocal.NewDayFactory(self.useJulian, self.doJump, self.db)
+	// The date slot is an AMAZON.DATE, which Alexa resolves to an ISO value;
+	// the phrase slot is an AMAZON.SearchQuery, which carries what was said.
+	dateValue, _ := request.GetSlotValue("date")
+	phraseValue, _ := request.GetSlotValue("phrase")
 
-	if when, e := request.GetSlotValue("date"); e == nil && len(when) > 0 {
-		date, e = time.ParseInLocation("2006-01-02", when, self.tz)
-		if e != nil {
-			response.OutputSpeech("I didn't understand the date you requested.")
-			return
-		}
-	} else {
-		now := time.Now().In(self.tz)
-		date = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, self.tz)
+	resolved, e := SpokenDate(dateValue, phraseValue, Today(self.tz), self.jurisdiction)
+	if e != nil {
+		response.OutputSpeech("I didn't understand the date you requested.")
+		return
 	}
+	date := time.Date(resolved.Year(), resolved.Month(), resolved.Day(), 0, 0, 0, 0, self.tz)
 
 	switch request.GetIntentName() {
 	case "Day":
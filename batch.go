@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/brianglass/orthocal"
+	"log"
+	"net/http"
+	"time"
+)
+
+const (
+	// Enough for every day of a year
+	BatchMaxDays  = 366
+	BatchMaxBytes = 64 * 1024
+)
+
+// BatchRequest asks for an arbitrary set of days. The days are returned in
+// the order requested.
+type BatchRequest struct {
+	Dates    []string `json:"dates"`
+	Passages bool     `json:"passages"`
+}
+
+func (self *CalendarServer) batchHandler(writer http.ResponseWriter, request *http.Request) {
+	var batch BatchRequest
+
+	body := http.MaxBytesReader(writer, request.Body, BatchMaxBytes)
+	if e := json.NewDecoder(body).Decode(&batch); e != nil {
+		writeError(writer, request, http.StatusBadRequest, `The request body must be a JSON object like {"dates": ["2025-04-20"]}.`)
+		return
+	}
+
+	if len(batch.Dates) == 0 {
+		writeError(writer, request, http.StatusBadRequest, "At least one date is required.")
+		return
+	}
+
+	if len(batch.Dates) > BatchMaxDays {
+		writeError(writer, request, http.StatusBadRequest, fmt.Sprintf("No more than %d dates may be requested at once.", BatchMaxDays))
+		return
+	}
+
+	// Validate everything before doing any work.
+	dates := make([]time.Time, 0, len(batch.Dates))
+	for _, value := range batch.Dates {
+		date, e := time.Parse("2006-01-02", value)
+		if e != nil {
+			writeError(writer, request, http.StatusBadRequest, fmt.Sprintf("'%s' is not formatted as YYYY-MM-DD.", value))
+			return
+		}
+
+		if !validYear(writer, request, date.Year()) {
+			return
+		}
+
+		dates = append(dates, date)
+	}
+
+	var bible orthocal.Bible
+	if batch.Passages {
+		bible = self.bible
+	}
+
+	ctx := request.Context()
+	factory := self.newDayFactory()
+
+	days := make([]DayResponse, 0, len(dates))
+	for _, date := range dates {
+		if e := ctx.Err(); e != nil {
+			log.Printf("Request canceled while generating days: %#v.", e)
+			return
+		}
+
+		d := factory.NewDayWithContext(ctx, date.Year(), int(date.Month()), date.Day(), bible)
+		days = append(days, NewDayResponse(d, self.jurisdiction))
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetIndent("", "\t")
+
+	if e := encoder.Encode(days); e != nil {
+		writeError(writer, request, http.StatusInternalServerError, "Internal Server Error")
+		log.Printf("Could not marshal json for batchHandler: %#v.", e)
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.Write(buf.Bytes())
+}
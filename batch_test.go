@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestBatch(t *testing.T) {
+	router, _ := newTestRouter(t, openTestCalendar(t), testBible{})
+
+	tests := []struct {
+		name     string
+		body     string
+		status   int
+		days     []int
+		passages bool
+	}{
+		{"in order", `{"dates": ["2025-12-25", "2025-01-06", "2025-04-20"]}`, http.StatusOK, []int{25, 6, 20}, false},
+		{"passages", `{"dates": ["2025-04-20"], "passages": true}`, http.StatusOK, []int{20}, true},
+		{"empty", `{"dates": []}`, http.StatusBadRequest, nil, false},
+		{"bad date", `{"dates": ["2025-04-20", "April 20"]}`, http.StatusBadRequest, nil, false},
+		{"out of range", `{"dates": ["1492-10-12"]}`, http.StatusNotFound, nil, false},
+		{"not json", `2025-04-20`, http.StatusBadRequest, nil, false},
+		{"too many", `{"dates": [` + strings.Repeat(`"2025-01-01", `, BatchMaxDays) + `"2025-01-01"]}`, http.StatusBadRequest, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest("POST", "/api/oca/days", strings.NewReader(tt.body))
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.status {
+				t.Fatalf("Expected status %d, got %d: %s", tt.status, recorder.Code, recorder.Body.String())
+			}
+
+			if tt.status != http.StatusOK {
+				return
+			}
+
+			var days []struct {
+				Day      int
+				Readings []struct{ Passage []json.RawMessage }
+			}
+			if e := json.Unmarshal(recorder.Body.Bytes(), &days); e != nil {
+				t.Fatalf("Could not unmarshal response: %v", e)
+			}
+
+			if len(days) != len(tt.days) {
+				t.Fatalf("Expected %d days, got %d", len(tt.days), len(days))
+			}
+
+			for i, day := range days {
+				if day.Day != tt.days[i] {
+					t.Errorf("Expected day %d to be %d, got %d", i, tt.days[i], day.Day)
+				}
+
+				if len(day.Readings) == 0 {
+					t.Fatalf("Expected day %d to have readings", i)
+				}
+
+				for _, reading := range day.Readings {
+					if (len(reading.Passage) > 0) != tt.passages {
+						t.Errorf("Expected day %d to have passages: %t", i, tt.passages)
+					}
+				}
+			}
+		})
+	}
+
+	// The batch endpoint only accepts POST.
+	request := httptest.NewRequest("GET", "/api/oca/days", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	checkErrorResponse(t, recorder, http.StatusMethodNotAllowed)
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"encoding/json"
+	"github.com/brianglass/orthocal"
+	"github.com/gorilla/mux"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// BibleServer looks up scripture passages by reference, independent of any
+// calendar day.
+type BibleServer struct {
+	bible orthocal.Bible
+}
+
+// PassageResponse is a passage broken into its verses.
+type PassageResponse struct {
+	Reference string          `json:"reference"`
+	Verses    []VerseResponse `json:"verses"`
+}
+
+type VerseResponse struct {
+	Book      string `json:"book"`
+	Chapter   int    `json:"chapter"`
+	Verse     int    `json:"verse"`
+	Text      string `json:"text"`
+	Paragraph bool   `json:"paragraph_start"`
+}
+
+func NewBibleServer(router *mux.Router, bible orthocal.Bible) *BibleServer {
+	var self BibleServer
+
+	self.bible = bible
+
+	router.NotFoundHandler = http.HandlerFunc(notFoundHandler)
+	router.MethodNotAllowedHandler = http.HandlerFunc(methodNotAllowedHandler)
+
+	r := router.Methods("GET", "HEAD").Subrouter()
+
+	r.HandleFunc(`/`, self.passageHandler)
+
+	return &self
+}
+
+// NewPassageResponse converts a passage into a response. Verse content
+// contains markup, which is stripped unless keepMarkup is true.
+func NewPassageResponse(reference string, passage orthocal.Passage, keepMarkup bool) PassageResponse {
+	response := PassageResponse{
+		Reference: reference,
+		Verses:    make([]VerseResponse, 0, len(passage)),
+	}
+
+	for _, verse := range passage {
+		text := verse.Content
+		if !keepMarkup {
+			text = strings.TrimSpace(markupRe.ReplaceAllString(text, ""))
+		}
+
+		response.Verses = append(response.Verses, VerseResponse{
+			Book:      verse.Book,
+			Chapter:   verse.Chapter,
+			Verse:     verse.Verse,
+			Text:      text,
+			Paragraph: verse.Paragraph,
+		})
+	}
+
+	return response
+}
+
+func (self *BibleServer) passageHandler(writer http.ResponseWriter, request *http.Request) {
+	if self.bible == nil {
+		writeError(writer, request, http.StatusServiceUnavailable, "No bible is configured.")
+		return
+	}
+
+	query := request.URL.Query()
+
+	reference := strings.TrimSpace(query.Get("ref"))
+	if len(reference) == 0 {
+		writeError(writer, request, http.StatusBadRequest, "The ref parameter is required.")
+		return
+	}
+
+	var markup bool
+	if value := query.Get("markup"); len(value) > 0 {
+		var e error
+		if markup, e = strconv.ParseBool(value); e != nil {
+			writeError(writer, request, http.StatusBadRequest, "The markup parameter must be true or false.")
+			return
+		}
+	}
+
+	etag := MakeETag("bible", reference, strconv.FormatBool(markup))
+	if checkNotModified(writer, request, etag, DataModified) {
+		return
+	}
+
+	passage := self.bible.Lookup(reference)
+	if len(passage) == 0 {
+		writeError(writer, request, http.StatusNotFound, "No passage was found for that reference.")
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.Header().Set("Cache-Control", CacheControl)
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "\t")
+
+	if e := encoder.Encode(NewPassageResponse(reference, passage, markup)); e != nil {
+		writeError(writer, request, http.StatusInternalServerError, "Internal Server Error")
+		log.Printf("Could not marshal json for passageHandler: %#v.", e)
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"github.com/brianglass/orthocal"
+	"github.com/gorilla/mux"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// testBible has a verse for every reference except those in the book of
+// Nonesuch.
+type testBible struct{}
+
+func (testBible) Lookup(reference string) orthocal.Passage {
+	if strings.HasPrefix(reference, "Nonesuch") {
+		return nil
+	}
+
+	return orthocal.Passage{
+		{Book: "John", Chapter: 1, Verse: 1, Content: "In the beginning was the Word", Paragraph: true},
+	}
+}
+
+func TestPassageHandler(t *testing.T) {
+	router := mux.NewRouter()
+	NewBibleServer(router.PathPrefix("/api/bible").Subrouter(), testBible{})
+
+	unconfigured := mux.NewRouter()
+	NewBibleServer(unconfigured.PathPrefix("/api/bible").Subrouter(), nil)
+
+	tests := []struct {
+		name   string
+		router *mux.Router
+		query  string
+		status int
+	}{
+		{"passage", router, "?ref=John+1.1", http.StatusOK},
+		{"missing ref", router, "", http.StatusBadRequest},
+		{"blank ref", router, "?ref=+", http.StatusBadRequest},
+		{"bad markup", router, "?ref=John+1.1&markup=maybe", http.StatusBadRequest},
+		{"unknown passage", router, "?ref=Nonesuch+1.1", http.StatusNotFound},
+		{"no bible", unconfigured, "?ref=John+1.1", http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", "/api/bible/"+tt.query, nil)
+			recorder := httptest.NewRecorder()
+			tt.router.ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.status {
+				t.Fatalf("Expected status %d, got %d: %s", tt.status, recorder.Code, recorder.Body.String())
+			}
+
+			if tt.status != http.StatusOK {
+				return
+			}
+
+			var response PassageResponse
+			if e := json.Unmarshal(recorder.Body.Bytes(), &response); e != nil {
+				t.Fatalf("Could not unmarshal response: %v", e)
+			}
+
+			if len(response.Verses) != 1 || response.Verses[0].Book != "John" {
+				t.Errorf("Expected John 1.1, got %v", response.Verses)
+			}
+		})
+	}
+}
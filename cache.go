@@ -0,0 +1,105 @@
+package main
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+var (
+	// DataVersion identifies the data and code that produced a response. It
+	// is folded into every ETag so that deploying new databases or a new
+	// binary invalidates whatever clients have cached.
+	DataVersion = "unknown"
+
+	// DataModified is the most recent modification time of the files that
+	// make up DataVersion. It is used for Last-Modified headers.
+	DataModified = time.Now().UTC()
+)
+
+// SetDataVersion computes DataVersion and DataModified from the names, sizes
+// and modification times of the given files.
+func SetDataVersion(paths ...string) error {
+	var modified time.Time
+
+	hash := sha1.New()
+	for _, path := range paths {
+		info, e := os.Stat(path)
+		if e != nil {
+			return e
+		}
+
+		fmt.Fprintf(hash, "%s\x00%d\x00%d\x00", path, info.Size(), info.ModTime().UnixNano())
+		if info.ModTime().After(modified) {
+			modified = info.ModTime()
+		}
+	}
+
+	DataVersion = fmt.Sprintf("%x", hash.Sum(nil))
+	DataModified = modified.UTC()
+
+	return nil
+}
+
+// MakeETag builds an entity tag from DataVersion and the given parts, which
+// should include everything that can change the body of a response. The tag
+// is weak because responses may be compressed, and the gzip and identity
+// encodings of a response are not byte for byte the same (RFC 7232, section
+// 2.1).
+func MakeETag(parts ...string) string {
+	hash := sha1.New()
+	fmt.Fprintf(hash, "%s\x00", DataVersion)
+	for _, part := range parts {
+		fmt.Fprintf(hash, "%s\x00", part)
+	}
+
+	return fmt.Sprintf(`W/"%x"`, hash.Sum(nil))
+}
+
+// checkNotModified sets the ETag and Last-Modified headers on a response and
+// then evaluates the request's If-None-Match and If-Modified-Since headers
+// against them. If the client's copy is still current, a 304 is written and
+// true is returned; the handler should not write anything further.
+func checkNotModified(writer http.ResponseWriter, request *http.Request, etag string, modified time.Time) bool {
+	// HTTP dates only have a resolution of one second.
+	modified = modified.UTC().Truncate(time.Second)
+
+	writer.Header().Set("ETag", etag)
+	writer.Header().Set("Last-Modified", modified.Format(http.TimeFormat))
+
+	// If-None-Match takes precedence over If-Modified-Since when both are
+	// present (RFC 7232, section 6).
+	if match := request.Header.Get("If-None-Match"); len(match) > 0 {
+		if !etagMatches(match, etag) {
+			return false
+		}
+	} else if since := request.Header.Get("If-Modified-Since"); len(since) > 0 {
+		t, e := http.ParseTime(since)
+		if e != nil || modified.After(t) {
+			return false
+		}
+	} else {
+		return false
+	}
+
+	writer.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// etagMatches uses the weak comparison function required for If-None-Match
+// to compare etag against each of the tags in header.
+func etagMatches(header, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCheckNotModified(t *testing.T) {
+	modified := time.Date(2024, 1, 7, 12, 0, 0, 0, time.UTC)
+	etag := MakeETag("OCA", "day", "2024", "1", "7")
+
+	testCases := []struct {
+		name        string
+		header      string
+		value       string
+		notModified bool
+	}{
+		{"No validators", "", "", false},
+		{"Matching ETag", "If-None-Match", etag, true},
+		{"Strong matching ETag", "If-None-Match", strings.TrimPrefix(etag, "W/"), true},
+		{"ETag in list", "If-None-Match", `"abc", ` + etag, true},
+		{"Wildcard", "If-None-Match", "*", true},
+		{"Different ETag", "If-None-Match", `"abc"`, false},
+		{"Not modified since", "If-Modified-Since", modified.Format(http.TimeFormat), true},
+		{"Modified since", "If-Modified-Since", modified.Add(-time.Hour).Format(http.TimeFormat), false},
+		{"Invalid date", "If-Modified-Since", "yesterday", false},
+	}
+
+	if !strings.HasPrefix(etag, `W/"`) {
+		t.Errorf("ETag should be weak but is %s", etag)
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", "/api/oca/2024/1/7/", nil)
+			if len(tc.header) > 0 {
+				request.Header.Set(tc.header, tc.value)
+			}
+			writer := httptest.NewRecorder()
+
+			notModified := checkNotModified(writer, request, etag, modified)
+			if notModified != tc.notModified {
+				t.Errorf("checkNotModified should be %t but is %t", tc.notModified, notModified)
+			}
+			if notModified && writer.Code != http.StatusNotModified {
+				t.Errorf("status should be %d but is %d", http.StatusNotModified, writer.Code)
+			}
+			if writer.Header().Get("ETag") != etag {
+				t.Errorf("ETag should be %s but is %s", etag, writer.Header().Get("ETag"))
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/brianglass/orthocal"
+	"github.com/gorilla/mux"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Comparison puts the same day in two jurisdictions side by side.
+type Comparison struct {
+	Date        string         `json:"date"`
+	A           ComparedDay    `json:"a"`
+	B           ComparedDay    `json:"b"`
+	Differences DayDifferences `json:"differences"`
+}
+
+type ComparedDay struct {
+	Jurisdiction string      `json:"jurisdiction"`
+	Day          DayResponse `json:"day"`
+}
+
+// DayDifferences describes how two days differ. Readings are described as
+// "Source, Description: Display".
+type DayDifferences struct {
+	Identical bool            `json:"identical"`
+	Titles    ListDifference  `json:"titles"`
+	Feasts    ListDifference  `json:"feasts"`
+	Saints    ListDifference  `json:"saints"`
+	Fasting   *FastDifference `json:"fasting,omitempty"`
+	Readings  ListDifference  `json:"readings"`
+}
+
+// ListDifference holds the items found in only one of the two lists.
+type ListDifference struct {
+	OnlyA []string `json:"only_a"`
+	OnlyB []string `json:"only_b"`
+}
+
+// FastDifference is only present when the fasting differs.
+type FastDifference struct {
+	A FastDescription `json:"a"`
+	B FastDescription `json:"b"`
+}
+
+type FastDescription struct {
+	FastLevel         int    `json:"fast_level"`
+	FastLevelDesc     string `json:"fast_level_desc"`
+	FastExceptionDesc string `json:"fast_exception_desc"`
+}
+
+// CompareDays computes the differences between two days.
+func CompareDays(a, b *orthocal.Day) DayDifferences {
+	readings := func(day *orthocal.Day) (items []string) {
+		for _, reading := range day.Readings {
+			items = append(items, readingSource(reading)+": "+reading.Display)
+		}
+		return items
+	}
+
+	d := DayDifferences{
+		Titles:   diffLists(a.Titles, b.Titles),
+		Feasts:   diffLists(a.Feasts, b.Feasts),
+		Saints:   diffLists(a.Saints, b.Saints),
+		Readings: diffLists(readings(a), readings(b)),
+	}
+
+	if a.FastLevel != b.FastLevel || a.FastLevelDesc != b.FastLevelDesc || a.FastExceptionDesc != b.FastExceptionDesc {
+		d.Fasting = &FastDifference{
+			A: FastDescription{a.FastLevel, a.FastLevelDesc, a.FastExceptionDesc},
+			B: FastDescription{b.FastLevel, b.FastLevelDesc, b.FastExceptionDesc},
+		}
+	}
+
+	d.Identical = d.Fasting == nil
+	for _, l := range []ListDifference{d.Titles, d.Feasts, d.Saints, d.Readings} {
+		if len(l.OnlyA) > 0 || len(l.OnlyB) > 0 {
+			d.Identical = false
+		}
+	}
+
+	return d
+}
+
+// diffLists returns the items of a that aren't in b and vice versa, keeping
+// their order. The slices are never nil.
+func diffLists(a, b []string) ListDifference {
+	missing := func(items, other []string) []string {
+		present := make(map[string]bool)
+		for _, item := range other {
+			present[item] = true
+		}
+
+		result := []string{}
+		for _, item := range items {
+			if !present[item] {
+				result = append(result, item)
+			}
+		}
+		return result
+	}
+
+	return ListDifference{
+		OnlyA: missing(a, b),
+		OnlyB: missing(b, a),
+	}
+}
+
+func (self *Directory) compareDayHandler(writer http.ResponseWriter, request *http.Request) {
+	// Mux is setup to only send YYYY-MM-DD style dates, so we don't need to
+	// handle the errors.
+	parts := strings.Split(mux.Vars(request)["date"], "-")
+	year, _ := strconv.Atoi(parts[0])
+	month, _ := strconv.Atoi(parts[1])
+	day, _ := strconv.Atoi(parts[2])
+
+	if !validDate(writer, request, year, month, day) {
+		return
+	}
+
+	start := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	self.writeComparisons(writer, request, start, start, "day")
+}
+
+func (self *Directory) compareMonthHandler(writer http.ResponseWriter, request *http.Request) {
+	// Mux is setup to only send YYYY-MM style months, so we don't need to
+	// handle the errors.
+	parts := strings.Split(mux.Vars(request)["month"], "-")
+	year, _ := strconv.Atoi(parts[0])
+	month, _ := strconv.Atoi(parts[1])
+
+	if !validDate(writer, request, year, month, 1) {
+		return
+	}
+
+	start := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	self.writeComparisons(writer, request, start, start.AddDate(0, 1, -1), "month")
+}
+
+// writeComparisons compares every day from start through end in the
+// jurisdictions named by the a and b query parameters. A single day is
+// written as an object and anything longer as an array.
+func (self *Directory) writeComparisons(writer http.ResponseWriter, request *http.Request, start, end time.Time, kind string) {
+	query := request.URL.Query()
+
+	var servers [2]*CalendarServer
+	for i, name := range []string{"a", "b"} {
+		slug := query.Get(name)
+		if len(slug) == 0 {
+			writeError(writer, request, http.StatusBadRequest, "Both the a and b jurisdictions are required.")
+			return
+		}
+
+		if servers[i] = self.Lookup(slug); servers[i] == nil {
+			writeError(writer, request, http.StatusNotFound, fmt.Sprintf("There is no jurisdiction named '%s'.", slug))
+			return
+		}
+	}
+
+	etag := MakeETag("compare", kind, start.Format("2006-01-02"), servers[0].jurisdiction.Slug, servers[1].jurisdiction.Slug)
+	if checkNotModified(writer, request, etag, DataModified) {
+		return
+	}
+
+	ctx := request.Context()
+	factoryA := servers[0].newDayFactory()
+	factoryB := servers[1].newDayFactory()
+
+	comparisons := []Comparison{}
+	for date := start; !date.After(end); date = date.AddDate(0, 0, 1) {
+		if e := ctx.Err(); e != nil {
+			log.Printf("Request canceled while comparing days: %#v.", e)
+			return
+		}
+
+		a := factoryA.NewDayWithContext(ctx, date.Year(), int(date.Month()), date.Day(), nil)
+		b := factoryB.NewDayWithContext(ctx, date.Year(), int(date.Month()), date.Day(), nil)
+
+		comparisons = append(comparisons, Comparison{
+			Date:        date.Format("2006-01-02"),
+			A:           ComparedDay{servers[0].jurisdiction.Slug, NewDayResponse(a, servers[0].jurisdiction)},
+			B:           ComparedDay{servers[1].jurisdiction.Slug, NewDayResponse(b, servers[1].jurisdiction)},
+			Differences: CompareDays(a, b),
+		})
+	}
+
+	var value interface{} = comparisons
+	if start.Equal(end) {
+		value = comparisons[0]
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetIndent("", "\t")
+
+	if e := encoder.Encode(value); e != nil {
+		writeError(writer, request, http.StatusInternalServerError, "Internal Server Error")
+		log.Printf("Could not marshal json for writeComparisons: %#v.", e)
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.Header().Set("Cache-Control", CacheControl)
+	writer.Write(buf.Bytes())
+}
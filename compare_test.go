@@ -0,0 +1,88 @@
+package main
+
+import (
+	"github.com/brianglass/orthocal"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestCompareDays(t *testing.T) {
+	a := &orthocal.Day{
+		Titles:    []string{"Sunday of the Paralytic"},
+		Saints:    []string{"St. Mark", "St. Basil"},
+		FastLevel: 0,
+		Readings: []orthocal.Reading{
+			{Source: "Epistle", Display: "Acts 9.32-42"},
+			{Source: "Gospel", Display: "John 5.1-15"},
+		},
+	}
+
+	b := &orthocal.Day{
+		Titles:        []string{"Sunday of the Paralytic"},
+		Saints:        []string{"St. Basil", "St. Cyril"},
+		FastLevel:     1,
+		FastLevelDesc: "Fast",
+		Readings: []orthocal.Reading{
+			{Source: "Epistle", Display: "Acts 9.32-42"},
+			{Source: "Gospel", Description: "Saint", Display: "Luke 6.17-23"},
+		},
+	}
+
+	d := CompareDays(a, b)
+
+	if d.Identical {
+		t.Errorf("the days should not be identical")
+	}
+
+	if len(d.Titles.OnlyA) != 0 || len(d.Titles.OnlyB) != 0 {
+		t.Errorf("the titles should not differ: %+v", d.Titles)
+	}
+
+	expected := ListDifference{OnlyA: []string{"St. Mark"}, OnlyB: []string{"St. Cyril"}}
+	if !reflect.DeepEqual(d.Saints, expected) {
+		t.Errorf("saints should be %+v but are %+v", expected, d.Saints)
+	}
+
+	expected = ListDifference{OnlyA: []string{"Gospel: John 5.1-15"}, OnlyB: []string{"Gospel, Saint: Luke 6.17-23"}}
+	if !reflect.DeepEqual(d.Readings, expected) {
+		t.Errorf("readings should be %+v but are %+v", expected, d.Readings)
+	}
+
+	if d.Fasting == nil || d.Fasting.A.FastLevel != 0 || d.Fasting.B.FastLevel != 1 {
+		t.Errorf("the fasting should differ: %+v", d.Fasting)
+	}
+
+	if d := CompareDays(a, a); !d.Identical || d.Fasting != nil {
+		t.Errorf("a day should be identical to itself: %+v", d)
+	}
+}
+
+func TestCompareRoutes(t *testing.T) {
+	router, _ := newTestRouter(t, nil, nil)
+
+	tests := []struct {
+		path   string
+		status int
+	}{
+		{"/api/compare/2025-02-30/?a=oca&b=rocor", http.StatusBadRequest},
+		{"/api/compare/2025-13/?a=oca&b=rocor", http.StatusBadRequest},
+		{"/api/compare/1492-10-12/?a=oca&b=rocor", http.StatusNotFound},
+		{"/api/compare/2025-04-20/?a=oca", http.StatusBadRequest},
+		{"/api/compare/2025-04-20/?a=oca&b=nonesuch", http.StatusNotFound},
+		{"/api/compare/2025/04/20/?a=oca&b=rocor", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			request := httptest.NewRequest("GET", tt.path, nil)
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.status {
+				t.Errorf("Expected status %d, got %d: %s", tt.status, recorder.Code, recorder.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+const (
+	DefaultConfigPath = "jurisdictions.json"
+
+	// The years the orthocal calendar database covers
+	DefaultFirstYear = 1900
+	DefaultLastYear  = 2099
+)
+
+var (
+	slugRe = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+	// These are paths under /api that are used by something other than a
+	// jurisdiction.
+	reservedSlugs = map[string]bool{
+		"bible":         true,
+		"compare":       true,
+		"convert":       true,
+		"jurisdictions": true,
+		"v2":            true,
+	}
+)
+
+// Config describes the databases the service opens and the jurisdictions it
+// serves. It is loaded from a JSON file at startup.
+type Config struct {
+	CalendarDB    string            `json:"calendar_db"`
+	Bibles        map[string]string `json:"bibles"`
+	DefaultBible  string            `json:"default_bible"`
+	Alexa         string            `json:"alexa"`
+	FirstYear     int               `json:"first_year"`
+	LastYear      int               `json:"last_year"`
+	Jurisdictions []Jurisdiction    `json:"jurisdictions"`
+}
+
+// Jurisdiction describes the calendar of one Orthodox jurisdiction. The same
+// definition is used by the HTTP API, the iCal feed and the Alexa skill.
+type Jurisdiction struct {
+	Slug      string `json:"slug"`
+	Title     string `json:"title"`
+	UseJulian bool   `json:"julian"`
+	DoJump    bool   `json:"lukan_jump"`
+	Bible     string `json:"bible"`
+	WebURL    string `json:"web_url"`
+}
+
+// LoadConfig reads and validates a configuration file. Jurisdictions without
+// a bible use the default bible and those without a web URL get one on
+// orthocal.info. The years covered default to those of the orthocal
+// calendar database.
+func LoadConfig(path string) (*Config, error) {
+	var config Config
+
+	content, e := os.ReadFile(path)
+	if e != nil {
+		return nil, e
+	}
+
+	if e := json.Unmarshal(content, &config); e != nil {
+		return nil, fmt.Errorf("Could not parse %s: %v", path, e)
+	}
+
+	if len(config.CalendarDB) == 0 {
+		return nil, fmt.Errorf("%s does not specify a calendar_db", path)
+	}
+
+	if _, ok := config.Bibles[config.DefaultBible]; !ok {
+		return nil, fmt.Errorf("The default bible '%s' is not one of the configured bibles", config.DefaultBible)
+	}
+
+	if config.FirstYear == 0 {
+		config.FirstYear = DefaultFirstYear
+	}
+	if config.LastYear == 0 {
+		config.LastYear = DefaultLastYear
+	}
+	if config.FirstYear > config.LastYear {
+		return nil, fmt.Errorf("The first_year %d is after the last_year %d", config.FirstYear, config.LastYear)
+	}
+
+	if len(config.Jurisdictions) == 0 {
+		return nil, fmt.Errorf("%s does not configure any jurisdictions", path)
+	}
+
+	seen := make(map[string]bool)
+	for i := range config.Jurisdictions {
+		j := &config.Jurisdictions[i]
+
+		if !slugRe.MatchString(j.Slug) || reservedSlugs[j.Slug] {
+			return nil, fmt.Errorf("'%s' is not a valid jurisdiction slug", j.Slug)
+		}
+		if seen[j.Slug] {
+			return nil, fmt.Errorf("The jurisdiction '%s' is configured more than once", j.Slug)
+		}
+		seen[j.Slug] = true
+
+		if len(j.Title) == 0 {
+			j.Title = strings.ToUpper(j.Slug)
+		}
+
+		if len(j.Bible) == 0 {
+			j.Bible = config.DefaultBible
+		} else if _, ok := config.Bibles[j.Bible]; !ok {
+			return nil, fmt.Errorf("The jurisdiction '%s' uses the unknown bible '%s'", j.Slug, j.Bible)
+		}
+
+		if len(j.WebURL) == 0 {
+			j.WebURL = WebBaseURL + "/calendar/" + j.Slug
+		}
+		j.WebURL = strings.TrimSuffix(j.WebURL, "/")
+	}
+
+	if len(config.Alexa) > 0 && config.Jurisdiction(config.Alexa) == nil {
+		return nil, fmt.Errorf("The Alexa skill uses the unknown jurisdiction '%s'", config.Alexa)
+	}
+
+	return &config, nil
+}
+
+// Jurisdiction returns the jurisdiction with the given slug or nil if there
+// isn't one.
+func (self *Config) Jurisdiction(slug string) *Jurisdiction {
+	for i := range self.Jurisdictions {
+		if self.Jurisdictions[i].Slug == slug {
+			return &self.Jurisdictions[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+func TestLoadConfig(t *testing.T) {
+	config, e := LoadConfig(DefaultConfigPath)
+	if e != nil {
+		t.Fatalf("Could not load %s: %v", DefaultConfigPath, e)
+	}
+
+	testCases := []struct {
+		slug      string
+		useJulian bool
+	}{
+		{"oca", false},
+		{"rocor", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.slug, func(t *testing.T) {
+			j := config.Jurisdiction(tc.slug)
+			if j == nil {
+				t.Fatalf("%s should be configured but isn't", tc.slug)
+			}
+			if j.UseJulian != tc.useJulian {
+				t.Errorf("%s should have julian %t but has %t", tc.slug, tc.useJulian, j.UseJulian)
+			}
+			if j.Bible != config.DefaultBible {
+				t.Errorf("%s should use the default bible but uses %s", tc.slug, j.Bible)
+			}
+		})
+	}
+
+	if config.FirstYear != DefaultFirstYear || config.LastYear != DefaultLastYear {
+		t.Errorf("The calendar should cover %d through %d, not %d through %d", DefaultFirstYear, DefaultLastYear, config.FirstYear, config.LastYear)
+	}
+
+	if config.Jurisdiction(config.Alexa) == nil {
+		t.Errorf("The Alexa skill should use a configured jurisdiction")
+	}
+}
+
+func TestShortSlugs(t *testing.T) {
+	// Each of these slugs is a prefix of a path that isn't a jurisdiction's.
+	config := &Config{
+		Jurisdictions: []Jurisdiction{
+			{Slug: "oca"},
+			{Slug: "oca-greek"},
+			{Slug: "v"},
+			{Slug: "b"},
+			{Slug: "j"},
+			{Slug: "o"},
+			{Slug: "c"},
+		},
+	}
+
+	router := mux.NewRouter()
+	mountAPI(router, config, nil, nil)
+
+	testCases := []struct {
+		path     string
+		template string
+	}{
+		{"/api/oca-greek/2025/4/20/", "/api/oca-greek/{year:\\d+}/{month:\\d+}/{day:\\d+}/"},
+		{"/api/v2/oca/2025/4/20/", "/api/v2/oca/{year:\\d+}/{month:\\d+}/{day:\\d+}/"},
+		{"/api/v/2025/4/20/", "/api/v/{year:\\d+}/{month:\\d+}/{day:\\d+}/"},
+		{"/api/oca/2025/4/20/", "/api/oca/{year:\\d+}/{month:\\d+}/{day:\\d+}/"},
+		{"/api/bible/", "/api/bible/"},
+		{"/api/jurisdictions", "/api/jurisdictions"},
+		{"/api/openapi.json", "/api/openapi.json"},
+		{"/api/compare/2025-04-20/", "/api/compare/{date:\\d+-\\d+-\\d+}/"},
+		{"/api/b/2025/4/20/", "/api/b/{year:\\d+}/{month:\\d+}/{day:\\d+}/"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			var match mux.RouteMatch
+			if !router.Match(httptest.NewRequest("GET", tc.path, nil), &match) || match.MatchErr != nil {
+				t.Fatalf("%s should match a route", tc.path)
+			}
+
+			template, _ := match.Route.GetPathTemplate()
+			if template != tc.template {
+				t.Errorf("%s should be served by %s, not %s", tc.path, tc.template, template)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/brianglass/orthocal"
+	"log"
+	"net/http"
+	"regexp"
+	"time"
+)
+
+var isoDateRe = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+
+// DayResponse is an orthocal.Day as the v1 API describes it. Jurisdictions
+// on the Julian calendar also get the old style date.
+type DayResponse struct {
+	*orthocal.Day
+	OldStyleDate string `json:"old_style_date,omitempty"`
+}
+
+func NewDayResponse(day *orthocal.Day, jurisdiction Jurisdiction) DayResponse {
+	response := DayResponse{Day: day}
+
+	if jurisdiction.UseJulian {
+		response.OldStyleDate = formatOldStyleDate(dayDate(day))
+	}
+
+	return response
+}
+
+// formatOldStyleDate returns the Julian date of a civil date as YYYY-MM-DD.
+// The Julian date may not exist on the Gregorian calendar, so time.Time
+// can't be used to format it.
+func formatOldStyleDate(date time.Time) string {
+	year, month, day := OldStyleDate(date)
+	return fmt.Sprintf("%04d-%02d-%02d", year, month, day)
+}
+
+// dualDate formats a civil date and, for jurisdictions on the Julian
+// calendar, follows it with the old style date, as in "January 7 (December
+// 25 old style)".
+func dualDate(date time.Time, layout string, jurisdiction Jurisdiction) string {
+	s := date.Format(layout)
+
+	if jurisdiction.UseJulian {
+		_, month, day := OldStyleDate(date)
+		s += fmt.Sprintf(" (%s %d old style)", time.Month(month), day)
+	}
+
+	return s
+}
+
+type ConversionResponse struct {
+	Date    string `json:"date"`
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Result  string `json:"result"`
+	Weekday string `json:"weekday"`
+}
+
+func (self *Directory) convertHandler(writer http.ResponseWriter, request *http.Request) {
+	query := request.URL.Query()
+
+	from, to := query.Get("from"), query.Get("to")
+	if len(from) == 0 {
+		from = GregorianCalendar
+	}
+	if len(to) == 0 {
+		to = JulianCalendar
+	}
+
+	for _, calendar := range []string{from, to} {
+		if _, ok := calendarToJDN[calendar]; !ok {
+			writeError(writer, request, http.StatusBadRequest, fmt.Sprintf("'%s' is not a calendar; use gregorian or julian.", calendar))
+			return
+		}
+	}
+
+	// Julian dates like 1900-02-29 don't exist on the Gregorian calendar, so
+	// time.Parse can't be used.
+	var year, month, day int
+	value := query.Get("date")
+	if !isoDateRe.MatchString(value) {
+		writeError(writer, request, http.StatusBadRequest, "The date is missing or is not formatted as YYYY-MM-DD.")
+		return
+	}
+
+	if _, e := fmt.Sscanf(value, "%d-%d-%d", &year, &month, &day); e != nil {
+		writeError(writer, request, http.StatusBadRequest, "The date is missing or is not formatted as YYYY-MM-DD.")
+		return
+	}
+
+	y, m, d, ok := ConvertDate(year, month, day, from, to)
+	if !ok {
+		writeError(writer, request, http.StatusBadRequest, fmt.Sprintf("%s is not a date on the %s calendar.", value, from))
+		return
+	}
+
+	// The weekday is the same on both calendars.
+	gy, gm, gd, _ := ConvertDate(year, month, day, from, GregorianCalendar)
+	weekday := time.Date(gy, time.Month(gm), gd, 0, 0, 0, 0, time.UTC).Weekday()
+
+	response := ConversionResponse{
+		Date:    value,
+		From:    from,
+		To:      to,
+		Result:  fmt.Sprintf("%04d-%02d-%02d", y, m, d),
+		Weekday: weekday.String(),
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.Header().Set("Cache-Control", YearCacheControl)
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "\t")
+
+	if e := encoder.Encode(response); e != nil {
+		writeError(writer, request, http.StatusInternalServerError, "Internal Server Error")
+		log.Printf("Could not marshal json for convertHandler: %#v.", e)
+	}
+}
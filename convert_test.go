@@ -0,0 +1,18 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDualDate(t *testing.T) {
+	date := time.Date(2025, time.January, 7, 0, 0, 0, 0, time.UTC)
+
+	if s := dualDate(date, "January 2", Jurisdiction{UseJulian: true}); s != "January 7 (December 25 old style)" {
+		t.Errorf("unexpected dual date %q", s)
+	}
+
+	if s := dualDate(date, "January 2", Jurisdiction{}); s != "January 7" {
+		t.Errorf("unexpected date %q", s)
+	}
+}
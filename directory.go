@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"log"
+	"net/http"
+	"sync"
+)
+
+// Directory knows about every mounted CalendarServer and serves the
+// endpoints that aren't specific to one jurisdiction.
+type Directory struct {
+	router  *mux.Router
+	servers []*CalendarServer
+
+	openAPIOnce     sync.Once
+	openAPIDocument []byte
+}
+
+// JurisdictionDescription tells clients about a mounted jurisdiction and
+// where to find its endpoints.
+type JurisdictionDescription struct {
+	Slug      string            `json:"slug"`
+	Title     string            `json:"title"`
+	UseJulian bool              `json:"julian"`
+	DoJump    bool              `json:"lukan_jump"`
+	URLs      map[string]string `json:"urls"`
+}
+
+func NewDirectory(router *mux.Router, servers []*CalendarServer) *Directory {
+	var self Directory
+
+	self.router = router
+	self.servers = servers
+
+	r := router.Methods("GET", "HEAD").Subrouter()
+
+	r.HandleFunc(`/api/jurisdictions`, self.jurisdictionsHandler)
+	r.HandleFunc(`/api/openapi.json`, self.openAPIHandler)
+	r.HandleFunc(`/api/convert/`, self.convertHandler)
+	r.HandleFunc(`/api/compare/{month:\d+-\d+}/`, self.compareMonthHandler)
+	r.HandleFunc(`/api/compare/{date:\d+-\d+-\d+}/`, self.compareDayHandler)
+
+	return &self
+}
+
+// Describe returns the details of the jurisdiction a server is mounted for.
+// URLs are path templates, such as /api/oca/{year}/{month}/{day}/.
+func (self *CalendarServer) Describe() JurisdictionDescription {
+	description := JurisdictionDescription{
+		Slug:      self.jurisdiction.Slug,
+		Title:     self.jurisdiction.Title,
+		UseJulian: self.jurisdiction.UseJulian,
+		DoJump:    self.jurisdiction.DoJump,
+		URLs:      make(map[string]string),
+	}
+
+	for name, route := range self.routes {
+		template, e := route.GetPathTemplate()
+		if e != nil {
+			log.Printf("Could not get the path template for %s: %#v.", name, e)
+			continue
+		}
+
+		description.URLs[name] = pathParamRe.ReplaceAllString(template, "{$1}")
+	}
+
+	return description
+}
+
+// Lookup returns the server for the jurisdiction with the given slug or nil
+// if there isn't one.
+func (self *Directory) Lookup(slug string) *CalendarServer {
+	for _, server := range self.servers {
+		if server.jurisdiction.Slug == slug {
+			return server
+		}
+	}
+
+	return nil
+}
+
+func (self *Directory) jurisdictionsHandler(writer http.ResponseWriter, request *http.Request) {
+	descriptions := make([]JurisdictionDescription, 0, len(self.servers))
+	for _, server := range self.servers {
+		descriptions = append(descriptions, server.Describe())
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.Header().Set("Cache-Control", CacheControl)
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "\t")
+
+	if e := encoder.Encode(descriptions); e != nil {
+		writeError(writer, request, http.StatusInternalServerError, "Internal Server Error")
+		log.Printf("Could not marshal json for jurisdictionsHandler: %#v.", e)
+	}
+}
+
+// The document can't be built until every route has been registered, so it
+// is built on the first request and kept from then on.
+func (self *Directory) openAPIHandler(writer http.ResponseWriter, request *http.Request) {
+	self.openAPIOnce.Do(func() {
+		slugs := make([]string, 0, len(self.servers))
+		for _, server := range self.servers {
+			slugs = append(slugs, server.jurisdiction.Slug)
+		}
+
+		document, e := BuildOpenAPI(self.router, slugs)
+		if e != nil {
+			log.Printf("The OpenAPI document is incomplete: %v.", e)
+		}
+
+		self.openAPIDocument, e = json.MarshalIndent(document, "", "\t")
+		if e != nil {
+			log.Printf("Could not marshal json for openAPIHandler: %#v.", e)
+		}
+	})
+
+	if self.openAPIDocument == nil {
+		writeError(writer, request, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.Header().Set("Cache-Control", CacheControl)
+	writer.Write(self.openAPIDocument)
+}
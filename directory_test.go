@@ -0,0 +1,66 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+func TestJurisdictionsHandler(t *testing.T) {
+	config, e := LoadConfig(DefaultConfigPath)
+	if e != nil {
+		t.Fatalf("Could not load %s: %v", DefaultConfigPath, e)
+	}
+
+	router := mux.NewRouter()
+	mountAPI(router, config, openTestCalendar(t), nil)
+
+	request := httptest.NewRequest("GET", "/api/jurisdictions", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", recorder.Code)
+	}
+
+	// Decode generically so that the field names are checked too.
+	var descriptions []map[string]interface{}
+	if e := json.Unmarshal(recorder.Body.Bytes(), &descriptions); e != nil {
+		t.Fatalf("Could not decode the jurisdictions: %v", e)
+	}
+
+	if len(descriptions) != len(config.Jurisdictions) {
+		t.Fatalf("Expected %d jurisdictions, got %d", len(config.Jurisdictions), len(descriptions))
+	}
+
+	for i, jurisdiction := range config.Jurisdictions {
+		description := descriptions[i]
+
+		if description["slug"] != jurisdiction.Slug {
+			t.Errorf("Expected slug %q, got %v", jurisdiction.Slug, description["slug"])
+		}
+		if description["title"] != jurisdiction.Title {
+			t.Errorf("%s should have title %q, got %v", jurisdiction.Slug, jurisdiction.Title, description["title"])
+		}
+		if description["julian"] != jurisdiction.UseJulian {
+			t.Errorf("%s should have julian %t, got %v", jurisdiction.Slug, jurisdiction.UseJulian, description["julian"])
+		}
+		if description["lukan_jump"] != jurisdiction.DoJump {
+			t.Errorf("%s should have lukan_jump %t, got %v", jurisdiction.Slug, jurisdiction.DoJump, description["lukan_jump"])
+		}
+
+		urls, _ := description["urls"].(map[string]interface{})
+		if day := "/api/" + jurisdiction.Slug + "/{year}/{month}/{day}/"; urls["day"] != day {
+			t.Errorf("%s should have the day URL %s, got %v", jurisdiction.Slug, day, urls["day"])
+		}
+	}
+
+	request = httptest.NewRequest("PUT", "/api/jurisdictions", nil)
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	checkErrorResponse(t, recorder, http.StatusMethodNotAllowed)
+}
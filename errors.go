@@ -0,0 +1,143 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+)
+
+const (
+	RequestIdHeader = "X-Request-Id"
+)
+
+var (
+	// The years covered by the calendar database, as configured by
+	// first_year and last_year. Requests for days outside of this range are
+	// answered with a 404.
+	FirstYear = DefaultFirstYear
+	LastYear  = DefaultLastYear
+)
+
+// ErrorResponse is the JSON envelope used for every error the API returns.
+type ErrorResponse struct {
+	Code      int    `json:"code"`
+	Message   string `json:"message"`
+	RequestId string `json:"request_id"`
+}
+
+// writeError writes a JSON error envelope with the given HTTP status code.
+func writeError(writer http.ResponseWriter, request *http.Request, code int, message string) {
+	response := ErrorResponse{
+		Code:      code,
+		Message:   message,
+		RequestId: requestId(request),
+	}
+
+	// Errors should never be cached, and any validators that were set
+	// before the error occurred no longer describe the response.
+	writer.Header().Del("ETag")
+	writer.Header().Del("Last-Modified")
+	writer.Header().Set("Cache-Control", "no-store")
+	writer.Header().Set("Content-Type", "application/json")
+	writer.Header().Set(RequestIdHeader, response.RequestId)
+	writer.WriteHeader(code)
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "\t")
+	if e := encoder.Encode(response); e != nil {
+		log.Printf("Could not marshal json for writeError: %#v.", e)
+	}
+}
+
+// notFoundHandler answers requests that don't match any route with the
+// standard error envelope.
+func notFoundHandler(writer http.ResponseWriter, request *http.Request) {
+	writeError(writer, request, http.StatusNotFound, "Not Found")
+}
+
+// methodNotAllowedHandler answers requests that match a route's path but not
+// its methods with the standard error envelope.
+func methodNotAllowedHandler(writer http.ResponseWriter, request *http.Request) {
+	writeError(writer, request, http.StatusMethodNotAllowed, "Method Not Allowed")
+}
+
+// requestId returns the id of a request, generating one if neither the
+// client nor requestIdMiddleware provided one.
+func requestId(request *http.Request) string {
+	if id := request.Header.Get(RequestIdHeader); len(id) > 0 {
+		return id
+	}
+
+	id := newRequestId()
+	request.Header.Set(RequestIdHeader, id)
+	return id
+}
+
+func newRequestId() string {
+	buf := make([]byte, 8)
+	if _, e := rand.Read(buf); e != nil {
+		// This should never happen, but the time is a reasonable fallback.
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+
+	return hex.EncodeToString(buf)
+}
+
+// requestIdMiddleware makes sure every request has an id and echoes it back
+// in the response so that clients can correlate errors with our logs.
+func requestIdMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		id := requestId(request)
+		writer.Header().Set(RequestIdHeader, id)
+		next.ServeHTTP(writer, request)
+	})
+}
+
+// validDate reports whether year, month and day form a date the calendar can
+// describe. If they don't, an error response is written: 400 for impossible
+// dates and 404 for dates outside of the calendar's coverage.
+func validDate(writer http.ResponseWriter, request *http.Request, year, month, day int) bool {
+	if month < 1 || month > 12 {
+		writeError(writer, request, http.StatusBadRequest, fmt.Sprintf("%d is not a valid month.", month))
+		return false
+	}
+
+	// time.Date normalizes out-of-range days, so a day is only valid if it
+	// survives the round trip.
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if day < 1 || date.Day() != day {
+		writeError(writer, request, http.StatusBadRequest, fmt.Sprintf("%d-%02d has no day %d.", year, month, day))
+		return false
+	}
+
+	if !validYear(writer, request, year) {
+		return false
+	}
+
+	return true
+}
+
+// validYear reports whether the calendar covers year, writing a 404 if it
+// doesn't.
+func validYear(writer http.ResponseWriter, request *http.Request, year int) bool {
+	if e := checkYear(year); e != nil {
+		writeError(writer, request, http.StatusNotFound, e.Error())
+		return false
+	}
+
+	return true
+}
+
+// checkYear returns an error if the calendar doesn't cover year. Every API
+// checks years this way so they all agree on the range.
+func checkYear(year int) error {
+	if year < FirstYear || year > LastYear {
+		return fmt.Errorf("The calendar only covers the years %d through %d.", FirstYear, LastYear)
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestValidDate(t *testing.T) {
+	testCases := []struct {
+		year, month, day int
+		status           int
+	}{
+		{2025, 4, 20, http.StatusOK},
+		{2024, 2, 29, http.StatusOK},
+		{2025, 2, 29, http.StatusBadRequest},
+		{2025, 13, 1, http.StatusBadRequest},
+		{2025, 4, 0, http.StatusBadRequest},
+		{1492, 10, 12, http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		request := httptest.NewRequest("GET", "/api/oca/", nil)
+		recorder := httptest.NewRecorder()
+
+		valid := validDate(recorder, request, tc.year, tc.month, tc.day)
+		if valid != (tc.status == http.StatusOK) {
+			t.Errorf("%d-%02d-%02d should be valid: %t", tc.year, tc.month, tc.day, !valid)
+		}
+
+		if !valid {
+			checkErrorResponse(t, recorder, tc.status)
+		}
+	}
+}
+
+func TestErrorHandlers(t *testing.T) {
+	testCases := []struct {
+		handler http.HandlerFunc
+		status  int
+	}{
+		{notFoundHandler, http.StatusNotFound},
+		{methodNotAllowedHandler, http.StatusMethodNotAllowed},
+	}
+
+	for _, tc := range testCases {
+		request := httptest.NewRequest("GET", "/api/oca/nothing/", nil)
+		recorder := httptest.NewRecorder()
+		tc.handler(recorder, request)
+
+		checkErrorResponse(t, recorder, tc.status)
+	}
+}
+
+// checkErrorResponse verifies that a response is an error envelope with the
+// given status.
+func checkErrorResponse(t *testing.T, recorder *httptest.ResponseRecorder, status int) {
+	t.Helper()
+
+	if recorder.Code != status {
+		t.Errorf("Expected status %d, got %d", status, recorder.Code)
+	}
+
+	var response ErrorResponse
+	if e := json.Unmarshal(recorder.Body.Bytes(), &response); e != nil {
+		t.Fatalf("Expected an error envelope, got %s", recorder.Body.String())
+	}
+
+	if response.Code != status {
+		t.Errorf("Expected the envelope code to be %d, got %d", status, response.Code)
+	}
+
+	if len(response.RequestId) == 0 || recorder.Header().Get(RequestIdHeader) != response.RequestId {
+		t.Errorf("Expected the request id %q in the %s header", response.RequestId, RequestIdHeader)
+	}
+}
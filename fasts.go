@@ -0,0 +1,156 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/brianglass/orthocal"
+	"github.com/gorilla/mux"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	FastingSeason = "fast"
+	FastFree      = "fast-free"
+)
+
+// FastingCalendar describes the fasting seasons and fast-free periods of a
+// year.
+type FastingCalendar struct {
+	Year    int             `json:"year"`
+	Periods []FastingPeriod `json:"periods"`
+}
+
+// FastingPeriod is a run of consecutive days sharing the same fasting rule.
+// Start and End are inclusive.
+type FastingPeriod struct {
+	Kind          string          `json:"kind"`
+	Start         string          `json:"start"`
+	End           string          `json:"end"`
+	FastLevel     int             `json:"fast_level"`
+	FastLevelDesc string          `json:"fast_level_desc"`
+	Exceptions    []FastException `json:"exceptions"`
+}
+
+// FastException records a day within a period on which the fast is relaxed.
+type FastException struct {
+	Date              string `json:"date"`
+	FastExceptionDesc string `json:"fast_exception_desc"`
+}
+
+// FastingPeriods groups consecutive days into periods. Only the four great
+// fasts (fast level 2 and above) and fast-free periods are reported; a
+// fast-free period is a run of non-fasting days that includes a Wednesday or
+// Friday, which would ordinarily be fast days. The days must be in order
+// and contiguous.
+func FastingPeriods(days []*orthocal.Day) []FastingPeriod {
+	periods := []FastingPeriod{}
+
+	for i := 0; i < len(days); {
+		// Find the end of the run of days with the same rule
+		j := i + 1
+		for j < len(days) && days[j].FastLevel == days[i].FastLevel && days[j].FastLevelDesc == days[i].FastLevelDesc {
+			j++
+		}
+
+		run := days[i:j]
+		i = j
+
+		var kind string
+		switch {
+		case run[0].FastLevel >= 2:
+			kind = FastingSeason
+		case run[0].FastLevel == 0 && includesFastDay(run):
+			kind = FastFree
+		default:
+			continue
+		}
+
+		period := FastingPeriod{
+			Kind:          kind,
+			Start:         dayDate(run[0]).Format("2006-01-02"),
+			End:           dayDate(run[len(run)-1]).Format("2006-01-02"),
+			FastLevel:     run[0].FastLevel,
+			FastLevelDesc: run[0].FastLevelDesc,
+			Exceptions:    []FastException{},
+		}
+
+		if kind == FastingSeason {
+			for _, day := range run {
+				if len(day.FastExceptionDesc) > 0 {
+					period.Exceptions = append(period.Exceptions, FastException{
+						Date:              dayDate(day).Format("2006-01-02"),
+						FastExceptionDesc: day.FastExceptionDesc,
+					})
+				}
+			}
+		}
+
+		periods = append(periods, period)
+	}
+
+	return periods
+}
+
+func includesFastDay(days []*orthocal.Day) bool {
+	for _, day := range days {
+		switch dayDate(day).Weekday() {
+		case time.Wednesday, time.Friday:
+			return true
+		}
+	}
+
+	return false
+}
+
+// dayDate returns the civil date of a day at midnight UTC.
+func dayDate(day *orthocal.Day) time.Time {
+	return time.Date(day.Year, time.Month(day.Month), day.Day, 0, 0, 0, 0, time.UTC)
+}
+
+func (self *CalendarServer) fastsHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	ctx := request.Context()
+
+	// Mux is setup to only send things that match this pattern, so we don't
+	// need to handle the errors.
+	year, _ := strconv.Atoi(vars["year"])
+
+	if !validYear(writer, request, year) {
+		return
+	}
+
+	factory := self.newDayFactory()
+
+	var days []*orthocal.Day
+	for date := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC); date.Year() == year; date = date.AddDate(0, 0, 1) {
+		if e := ctx.Err(); e != nil {
+			log.Printf("Request canceled while generating fasts: %#v.", e)
+			return
+		}
+
+		days = append(days, factory.NewDayWithContext(ctx, date.Year(), int(date.Month()), date.Day(), nil))
+	}
+
+	calendar := FastingCalendar{
+		Year:    year,
+		Periods: FastingPeriods(days),
+	}
+
+	// Encode into a buffer so that a failure can still be reported cleanly.
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetIndent("", "\t")
+
+	if e := encoder.Encode(calendar); e != nil {
+		writeError(writer, request, http.StatusInternalServerError, "Internal Server Error")
+		log.Printf("Could not marshal json for fastsHandler: %#v.", e)
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.Header().Set("Cache-Control", YearCacheControl)
+	writer.Write(buf.Bytes())
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"github.com/brianglass/orthocal"
+	"testing"
+)
+
+func TestFastingPeriods(t *testing.T) {
+	// Monday, April 29, 2024 through Saturday, May 18, 2024: Holy Week,
+	// Bright Week and a few days of ordinary Wednesday and Friday fasts.
+	levels := []int{2, 2, 2, 2, 2, 2, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 1, 0}
+	var days []*orthocal.Day
+	for i, level := range levels {
+		day := &orthocal.Day{Year: 2024, Month: 4, Day: 29 + i, FastLevel: level}
+		// Normalize the date
+		date := dayDate(day)
+		day.Year, day.Month, day.Day = date.Year(), int(date.Month()), date.Day()
+
+		switch level {
+		case 2:
+			day.FastLevelDesc = "Lenten Fast"
+		case 1:
+			day.FastLevelDesc = "Fast"
+		default:
+			day.FastLevelDesc = "No Fast"
+		}
+		days = append(days, day)
+	}
+	days[5].FastExceptionDesc = "Strict Fast"
+
+	periods := FastingPeriods(days)
+	if len(periods) != 2 {
+		t.Fatalf("There should be 2 periods but there are %d: %#v", len(periods), periods)
+	}
+
+	lent, bright := periods[0], periods[1]
+	if lent.Kind != FastingSeason || lent.Start != "2024-04-29" || lent.End != "2024-05-04" {
+		t.Errorf("Unexpected fasting season %#v", lent)
+	}
+	if len(lent.Exceptions) != 1 || lent.Exceptions[0].Date != "2024-05-04" {
+		t.Errorf("Unexpected exceptions %#v", lent.Exceptions)
+	}
+	if bright.Kind != FastFree || bright.Start != "2024-05-05" || bright.End != "2024-05-14" {
+		t.Errorf("Unexpected fast-free period %#v", bright)
+	}
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// FieldSet is a client's selection of fields from a JSON response, as given
+// by a fields query parameter like "titles,fast_level,readings.display".
+// Each key maps to the selection within that field; nil selects the whole
+// field. A nil FieldSet selects everything.
+type FieldSet map[string]FieldSet
+
+var dayType = reflect.TypeOf(DayResponse{})
+
+// ParseFields parses a comma separated list of dotted field paths, checking
+// each against the JSON encoding of t.
+func ParseFields(value string, t reflect.Type) (FieldSet, error) {
+	var fields FieldSet
+
+	for _, path := range strings.Split(value, ",") {
+		path = strings.TrimSpace(path)
+		if len(path) == 0 {
+			continue
+		}
+
+		if fields == nil {
+			fields = make(FieldSet)
+		}
+
+		set, typ := fields, t
+		names := strings.Split(path, ".")
+		for i, name := range names {
+			var ok bool
+			if typ, ok = jsonField(typ, name); !ok {
+				return nil, fmt.Errorf("There is no field named '%s'.", path)
+			}
+
+			sub, exists := set[name]
+			if i == len(names)-1 {
+				// Selecting a whole field overrides any selection within it.
+				set[name] = nil
+				break
+			}
+
+			if exists && sub == nil {
+				// The whole field is already selected.
+				break
+			}
+
+			if sub == nil {
+				sub = make(FieldSet)
+				set[name] = sub
+			}
+			set = sub
+		}
+	}
+
+	return fields, nil
+}
+
+// jsonField returns the type of the field that encoding/json names name in
+// values of type t. Slices and pointers are looked through.
+func jsonField(t reflect.Type, name string) (reflect.Type, bool) {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return nil, false
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if field.Anonymous {
+			if ft, ok := jsonField(field.Type, name); ok {
+				return ft, true
+			}
+			continue
+		}
+
+		if field.PkgPath != "" {
+			continue
+		}
+
+		tag := strings.Split(field.Tag.Get("json"), ",")[0]
+		if tag == "-" {
+			continue
+		}
+		if len(tag) == 0 {
+			tag = field.Name
+		}
+
+		if tag == name {
+			return field.Type, true
+		}
+	}
+
+	return nil, false
+}
+
+// Includes returns true if any part of the field at path is selected.
+func (self FieldSet) Includes(path ...string) bool {
+	set := self
+	for _, name := range path {
+		if set == nil {
+			return true
+		}
+
+		sub, ok := set[name]
+		if !ok {
+			return false
+		}
+		set = sub
+	}
+
+	return true
+}
+
+// Filter removes every unselected field from a decoded JSON value.
+func (self FieldSet) Filter(value interface{}) interface{} {
+	if self == nil {
+		return value
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key := range v {
+			if sub, ok := self[key]; ok {
+				v[key] = sub.Filter(v[key])
+			} else {
+				delete(v, key)
+			}
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = self.Filter(v[i])
+		}
+	}
+
+	return value
+}
+
+// Select returns the selected fields of value, ready to be encoded. Value is
+// returned untouched if everything is selected.
+func (self FieldSet) Select(value interface{}) (interface{}, error) {
+	if self == nil {
+		return value, nil
+	}
+
+	b, e := json.Marshal(value)
+	if e != nil {
+		return nil, e
+	}
+
+	var decoded interface{}
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	if e := decoder.Decode(&decoded); e != nil {
+		return nil, e
+	}
+
+	return self.Filter(decoded), nil
+}
+
+// String returns the selection in a canonical form, suitable for an ETag.
+func (self FieldSet) String() string {
+	if self == nil {
+		return ""
+	}
+
+	b, _ := json.Marshal(self)
+	return string(b)
+}
+
+// requestFields returns the fields of a day the client asked for, or nil if
+// they want everything.
+func requestFields(request *http.Request) (FieldSet, error) {
+	return ParseFields(request.URL.Query().Get("fields"), dayType)
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"encoding/json"
+	"github.com/brianglass/orthocal"
+	"testing"
+)
+
+func TestParseFields(t *testing.T) {
+	testCases := []struct {
+		fields   string
+		ok       bool
+		canon    string
+		passages bool
+	}{
+		{"", true, "", true},
+		{"titles,fast_level", true, `{"fast_level":null,"titles":null}`, false},
+		{"titles, readings.display", true, `{"readings":{"display":null},"titles":null}`, false},
+		{"readings", true, `{"readings":null}`, true},
+		{"readings.display,readings", true, `{"readings":null}`, true},
+		{"readings,readings.display", true, `{"readings":null}`, true},
+		{"readings.passage.content", true, `{"readings":{"passage":{"content":null}}}`, true},
+		{"nonesuch", false, "", false},
+		{"titles.nonesuch", false, "", false},
+		{"readings.nonesuch", false, "", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.fields, func(t *testing.T) {
+			fields, e := ParseFields(tc.fields, dayType)
+			if ok := e == nil; ok != tc.ok {
+				t.Fatalf("ok should be %t but got error %v", tc.ok, e)
+			}
+			if !tc.ok {
+				return
+			}
+
+			if canon := fields.String(); canon != tc.canon {
+				t.Errorf("fields should be %s but are %s", tc.canon, canon)
+			}
+
+			if passages := fields.Includes("readings", "passage"); passages != tc.passages {
+				t.Errorf("passages should be %t but are %t", tc.passages, passages)
+			}
+		})
+	}
+}
+
+func TestSelectFields(t *testing.T) {
+	day := orthocal.Day{
+		Titles:    []string{"Pascha"},
+		FastLevel: 0,
+		Readings: []orthocal.Reading{
+			{Source: "Liturgy", Display: "Acts 1.1-8"},
+			{Source: "Liturgy", Display: "John 1.1-17"},
+		},
+	}
+
+	fields, e := ParseFields("titles,readings.display", dayType)
+	if e != nil {
+		t.Fatalf("%v", e)
+	}
+
+	value, e := fields.Select(&day)
+	if e != nil {
+		t.Fatalf("%v", e)
+	}
+
+	b, _ := json.Marshal(value)
+	expected := `{"readings":[{"display":"Acts 1.1-8"},{"display":"John 1.1-17"}],"titles":["Pascha"]}`
+	if string(b) != expected {
+		t.Errorf("selection should be %s but is %s", expected, b)
+	}
+}
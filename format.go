@@ -0,0 +1,298 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	alexa "github.com/brianglass/go-alexa/skillserver"
+	"github.com/brianglass/orthocal"
+	"html"
+	"io"
+	"mime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FormatOptions carries the details of a request that a DayFormatter may
+// need beyond the day itself.
+type FormatOptions struct {
+	Jurisdiction Jurisdiction
+	TZ           *time.Location
+	Stamp        time.Time
+
+	// Fields limits the JSON format to the fields the client selected.
+	Fields FieldSet
+}
+
+// A DayFormatter renders a single day in some media type.
+type DayFormatter func(writer io.Writer, day *orthocal.Day, options FormatOptions) error
+
+// DayFormat associates a DayFormatter with the media type it produces.
+type DayFormat struct {
+	MediaType   string
+	ContentType string
+	Format      DayFormatter
+
+	// Relative is true if the rendering depends on the current date, as in
+	// "Today, January 7."
+	Relative bool
+}
+
+// dayFormats is the registry of renderings available for days. The order is
+// significant: when a client accepts several formats equally, the earliest
+// one wins.
+var dayFormats []DayFormat
+
+func init() {
+	RegisterDayFormat("application/json", "application/json", false, JSONDayFormatter)
+	RegisterDayFormat("text/plain", "text/plain; charset=utf-8", true, TextDayFormatter)
+	RegisterDayFormat("text/markdown", "text/markdown; charset=utf-8", false, MarkdownDayFormatter)
+	RegisterDayFormat("text/html", "text/html; charset=utf-8", false, HTMLDayFormatter)
+	RegisterDayFormat("text/calendar", "text/calendar", false, ICalDayFormatter)
+}
+
+// RegisterDayFormat makes a formatter available for content negotiation on
+// the day endpoints.
+func RegisterDayFormat(mediaType, contentType string, relative bool, formatter DayFormatter) {
+	dayFormats = append(dayFormats, DayFormat{
+		MediaType:   mediaType,
+		ContentType: contentType,
+		Format:      formatter,
+		Relative:    relative,
+	})
+}
+
+// NegotiateDayFormat picks the registered format that best satisfies an
+// Accept header. The second result is false if none of them are acceptable.
+func NegotiateDayFormat(accept string) (DayFormat, bool) {
+	if len(strings.TrimSpace(accept)) == 0 {
+		return dayFormats[0], true
+	}
+
+	ranges := parseAccept(accept)
+
+	best, bestQ := -1, 0.0
+	for i, format := range dayFormats {
+		if q := acceptQuality(ranges, format.MediaType); q > bestQ {
+			best, bestQ = i, q
+		}
+	}
+
+	if best < 0 {
+		return DayFormat{}, false
+	}
+
+	return dayFormats[best], true
+}
+
+// PrefersNDJSON returns true if the client would rather receive a list of
+// days as newline delimited JSON than as a JSON array.
+func PrefersNDJSON(accept string) bool {
+	ranges := parseAccept(accept)
+
+	q := acceptQuality(ranges, NDJSONMediaType)
+	return q > 0 && q > acceptQuality(ranges, "application/json")
+}
+
+type mediaRange struct {
+	mediaType string
+	q         float64
+}
+
+func parseAccept(accept string) (ranges []mediaRange) {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, e := mime.ParseMediaType(strings.TrimSpace(part))
+		if e != nil {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := params["q"]; ok {
+			if q, e = strconv.ParseFloat(value, 64); e != nil {
+				continue
+			}
+		}
+
+		ranges = append(ranges, mediaRange{mediaType, q})
+	}
+
+	return ranges
+}
+
+// acceptQuality returns the quality the client assigned to mediaType, using
+// the most specific matching range as RFC 7231 requires.
+func acceptQuality(ranges []mediaRange, mediaType string) float64 {
+	major := strings.SplitN(mediaType, "/", 2)[0]
+
+	q, specificity := 0.0, -1
+	for _, r := range ranges {
+		var s int
+		switch r.mediaType {
+		case mediaType:
+			s = 2
+		case major + "/*":
+			s = 1
+		case "*/*":
+			s = 0
+		default:
+			continue
+		}
+
+		if s > specificity {
+			q, specificity = r.q, s
+		}
+	}
+
+	return q
+}
+
+func JSONDayFormatter(writer io.Writer, day *orthocal.Day, options FormatOptions) error {
+	value, e := options.Fields.Select(NewDayResponse(day, options.Jurisdiction))
+	if e != nil {
+		return e
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "\t")
+	return encoder.Encode(value)
+}
+
+// TextDayFormatter produces the same summary that appears on the Alexa card.
+func TextDayFormatter(writer io.Writer, day *orthocal.Day, options FormatOptions) error {
+	card := DaySpeech(alexa.NewSSMLTextBuilder(), day, options.TZ)
+
+	if options.Jurisdiction.UseJulian {
+		card = dualDate(dayDate(day), "January 2", options.Jurisdiction) + "\n\n" + card
+	}
+
+	_, e := io.WriteString(writer, card)
+	return e
+}
+
+func MarkdownDayFormatter(writer io.Writer, day *orthocal.Day, options FormatOptions) error {
+	var s string
+
+	date := time.Date(day.Year, time.Month(day.Month), day.Day, 0, 0, 0, 0, time.UTC)
+	s += fmt.Sprintf("# %s\n\n", dualDate(date, "Monday, January 2, 2006", options.Jurisdiction))
+
+	for _, title := range day.Titles {
+		s += fmt.Sprintf("**%s**\n\n", title)
+	}
+
+	if fasting := fastingText(day); len(fasting) > 0 {
+		s += fmt.Sprintf("*%s*\n\n", fasting)
+	}
+
+	if len(day.Feasts) > 0 {
+		s += "## Feasts\n\n"
+		for _, feast := range day.Feasts {
+			s += fmt.Sprintf("- %s\n", feast)
+		}
+		s += "\n"
+	}
+
+	if len(day.Saints) > 0 {
+		s += "## Commemorations\n\n"
+		for _, saint := range day.Saints {
+			s += fmt.Sprintf("- %s\n", saint)
+		}
+		s += "\n"
+	}
+
+	if len(day.Readings) > 0 {
+		s += "## Readings\n\n"
+		for _, reading := range day.Readings {
+			s += fmt.Sprintf("- **%s** (%s)\n", reading.Display, readingSource(reading))
+		}
+		s += "\n"
+	}
+
+	for _, reading := range day.Readings {
+		if len(reading.Passage) == 0 {
+			continue
+		}
+
+		s += fmt.Sprintf("### %s\n\n", reading.Display)
+		for _, verse := range reading.Passage {
+			s += markupRe.ReplaceAllString(verse.Content, "") + "\n"
+		}
+		s += "\n"
+	}
+
+	_, e := io.WriteString(writer, s)
+	return e
+}
+
+// HTMLDayFormatter produces a fragment suitable for embedding in a page.
+func HTMLDayFormatter(writer io.Writer, day *orthocal.Day, options FormatOptions) error {
+	var s string
+
+	date := time.Date(day.Year, time.Month(day.Month), day.Day, 0, 0, 0, 0, time.UTC)
+	s += fmt.Sprintf("<article class=\"orthocal-day\" data-date=\"%s\">\n", date.Format("2006-01-02"))
+	s += fmt.Sprintf("<h1>%s</h1>\n", dualDate(date, "Monday, January 2, 2006", options.Jurisdiction))
+
+	for _, title := range day.Titles {
+		s += fmt.Sprintf("<h2>%s</h2>\n", html.EscapeString(title))
+	}
+
+	if fasting := fastingText(day); len(fasting) > 0 {
+		s += fmt.Sprintf("<p class=\"fasting\">%s</p>\n", html.EscapeString(fasting))
+	}
+
+	s += htmlList("feasts", day.Feasts)
+	s += htmlList("commemorations", day.Saints)
+
+	if len(day.Readings) > 0 {
+		s += "<section class=\"readings\">\n"
+		for _, reading := range day.Readings {
+			s += fmt.Sprintf("<h3>%s <small>%s</small></h3>\n", html.EscapeString(reading.Display), html.EscapeString(readingSource(reading)))
+			for _, verse := range reading.Passage {
+				s += fmt.Sprintf("<p>%s</p>\n", html.EscapeString(markupRe.ReplaceAllString(verse.Content, "")))
+			}
+		}
+		s += "</section>\n"
+	}
+
+	s += "</article>\n"
+
+	_, e := io.WriteString(writer, s)
+	return e
+}
+
+// ICalDayFormatter produces a calendar containing a single event.
+func ICalDayFormatter(writer io.Writer, day *orthocal.Day, options FormatOptions) error {
+	writeCalendarHeader(writer, options.Jurisdiction.Title, options.TZ)
+	writeCalendarEvent(writer, day, options.Jurisdiction, options.Stamp)
+	_, e := fmt.Fprintf(writer, "END:VCALENDAR")
+	return e
+}
+
+func fastingText(day *orthocal.Day) string {
+	if len(day.FastExceptionDesc) > 0 && day.FastLevel > 0 {
+		return fmt.Sprintf("%s \u2013 %s", day.FastLevelDesc, day.FastExceptionDesc)
+	}
+
+	return day.FastLevelDesc
+}
+
+func readingSource(reading orthocal.Reading) string {
+	if len(reading.Description) > 0 {
+		return reading.Source + ", " + reading.Description
+	}
+
+	return reading.Source
+}
+
+func htmlList(class string, items []string) (s string) {
+	if len(items) == 0 {
+		return s
+	}
+
+	s += fmt.Sprintf("<ul class=\"%s\">\n", class)
+	for _, item := range items {
+		s += fmt.Sprintf("<li>%s</li>\n", html.EscapeString(item))
+	}
+	s += "</ul>\n"
+
+	return s
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"database/sql"
+	"github.com/gorilla/mux"
+	_ "github.com/mattn/go-sqlite3"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNegotiateDayFormat(t *testing.T) {
+	testCases := []struct {
+		accept    string
+		mediaType string
+		ok        bool
+	}{
+		{"", "application/json", true},
+		{"*/*", "application/json", true},
+		{"text/markdown", "text/markdown", true},
+		{"text/html, application/xhtml+xml, */*;q=0.8", "text/html", true},
+		{"text/*", "text/plain", true},
+		{"text/*;q=0.5, application/json;q=0.2", "text/plain", true},
+		{"text/*, text/plain;q=0", "text/markdown", true},
+		{"text/calendar;q=1, application/json;q=0.9", "text/calendar", true},
+		{"image/png", "", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.accept, func(t *testing.T) {
+			format, ok := NegotiateDayFormat(tc.accept)
+			if ok != tc.ok {
+				t.Fatalf("ok should be %t but is %t", tc.ok, ok)
+			}
+			if format.MediaType != tc.mediaType {
+				t.Errorf("media type should be %s but is %s", tc.mediaType, format.MediaType)
+			}
+		})
+	}
+}
+
+// openTestCalendar opens the calendar database for tests that generate days.
+func openTestCalendar(t *testing.T) *sql.DB {
+	db, e := sql.Open("sqlite3", "oca_calendar.db")
+	if e != nil {
+		t.Fatalf("Got error opening database: %#v.", e)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
+
+func TestRelativeDayRendering(t *testing.T) {
+	router := mux.NewRouter()
+	NewCalendarServer(router.PathPrefix("/api/oca/").Subrouter(), openTestCalendar(t), Jurisdiction{Slug: "oca", Title: "OCA", DoJump: true}, nil)
+
+	// Data that is older than today
+	modified := DataModified
+	DataModified = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	defer func() { DataModified = modified }()
+
+	request := httptest.NewRequest("GET", "/api/oca/2025/4/20/?tz=Pacific/Kiritimati", nil)
+	request.Header.Set("Accept", "text/plain")
+	request.Header.Set("If-Modified-Since", DataModified.Format(http.TimeFormat))
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	// The rendering changes with today, so it's newer than the data.
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, recorder.Code)
+	}
+
+	tz, _ := time.LoadLocation("Pacific/Kiritimati")
+	now := time.Now().In(tz)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, tz)
+	if lastModified := recorder.Header().Get("Last-Modified"); lastModified != today.UTC().Format(http.TimeFormat) {
+		t.Errorf("Expected Last-Modified to be the start of today in the requested zone, got %s", lastModified)
+	}
+
+	request = httptest.NewRequest("GET", "/api/oca/2025/4/20/?tz=Mars/Olympus", nil)
+	request.Header.Set("Accept", "text/plain")
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for an unknown time zone, got %d", http.StatusBadRequest, recorder.Code)
+	}
+}
+
+func TestPrefersNDJSON(t *testing.T) {
+	testCases := []struct {
+		accept string
+		ndjson bool
+	}{
+		{"", false},
+		{"*/*", false},
+		{"application/json", false},
+		{"application/x-ndjson", true},
+		{"application/x-ndjson, application/json;q=0.9", true},
+		{"application/json, application/x-ndjson;q=0.5", false},
+		{"application/*", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.accept, func(t *testing.T) {
+			if ndjson := PrefersNDJSON(tc.accept); ndjson != tc.ndjson {
+				t.Errorf("PrefersNDJSON should be %t but is %t", tc.ndjson, ndjson)
+			}
+		})
+	}
+}
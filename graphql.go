@@ -0,0 +1,522 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/brianglass/orthocal"
+	"github.com/gorilla/mux"
+	graphql "github.com/graph-gophers/graphql-go"
+	"log"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	GraphQLMaxDepth       = 10
+	GraphQLMaxQueryLength = 8192
+	GraphQLMaxDays        = 31
+
+	// Each query has a budget that is spent as days are generated and
+	// passages are looked up. Passage lookups are by far the most expensive
+	// thing we do.
+	GraphQLMaxComplexity = 400
+	GraphQLDayCost       = 1
+	GraphQLPassageCost   = 10
+)
+
+const graphqlSchema = `
+	schema {
+		query: Query
+	}
+
+	type Query {
+		jurisdictions: [Jurisdiction!]!
+		jurisdiction(slug: String!): Jurisdiction
+		passage(reference: String!): Passage
+	}
+
+	type Jurisdiction {
+		slug: String!
+		title: String!
+		julian: Boolean!
+		lukanJump: Boolean!
+		# Today in the given IANA time zone
+		today(tz: String): Day!
+		# A day given as YYYY-MM-DD
+		day(date: String!): Day!
+		# Consecutive days beginning with start, which defaults to today
+		days(start: String, count: Int = 7, tz: String): [Day!]!
+	}
+
+	type Day {
+		date: String!
+		# The Julian calendar date, for jurisdictions on the Julian calendar
+		oldStyleDate: String
+		weekday: String!
+		titles: [String!]!
+		feasts: [String!]!
+		saints: [String!]!
+		commemorations: [Commemoration!]!
+		fastLevel: Int!
+		fastLevelDesc: String!
+		fastExceptionDesc: String!
+		readings: [Reading!]!
+	}
+
+	type Commemoration {
+		kind: String!
+		name: String!
+	}
+
+	type Reading {
+		source: String!
+		book: String!
+		description: String!
+		display: String!
+		references: [ScriptureRange!]!
+		# Looking up the passage is expensive, so only ask for it if you need it
+		passage: Passage
+	}
+
+	type ScriptureRange {
+		book: String!
+		startChapter: Int!
+		startVerse: Int!
+		endChapter: Int!
+		endVerse: Int!
+	}
+
+	type Passage {
+		reference: String!
+		verses: [Verse!]!
+	}
+
+	type Verse {
+		book: String!
+		chapter: Int!
+		verse: Int!
+		text: String!
+		paragraphStart: Boolean!
+	}
+`
+
+// GraphQLServer answers GraphQL queries over every mounted jurisdiction.
+type GraphQLServer struct {
+	schema *graphql.Schema
+}
+
+func NewGraphQLServer(router *mux.Router, servers []*CalendarServer, bible orthocal.Bible) *GraphQLServer {
+	var self GraphQLServer
+
+	resolver := &graphqlResolver{servers: servers, bible: bible}
+	self.schema = graphql.MustParseSchema(graphqlSchema, resolver,
+		graphql.MaxDepth(GraphQLMaxDepth),
+		graphql.UseFieldResolvers(),
+	)
+
+	router.Methods("GET", "POST").Path(`/graphql`).HandlerFunc(self.graphqlHandler)
+
+	return &self
+}
+
+func (self *GraphQLServer) graphqlHandler(writer http.ResponseWriter, request *http.Request) {
+	var params struct {
+		Query         string                 `json:"query"`
+		OperationName string                 `json:"operationName"`
+		Variables     map[string]interface{} `json:"variables"`
+	}
+
+	if request.Method == "POST" {
+		body := http.MaxBytesReader(writer, request.Body, 2*GraphQLMaxQueryLength)
+		if e := json.NewDecoder(body).Decode(&params); e != nil {
+			writeError(writer, request, http.StatusBadRequest, "The request body must be a JSON GraphQL request.")
+			return
+		}
+	} else {
+		query := request.URL.Query()
+		params.Query = query.Get("query")
+		params.OperationName = query.Get("operationName")
+		if variables := query.Get("variables"); len(variables) > 0 {
+			if e := json.Unmarshal([]byte(variables), &params.Variables); e != nil {
+				writeError(writer, request, http.StatusBadRequest, "The variables parameter must be a JSON object.")
+				return
+			}
+		}
+	}
+
+	if len(params.Query) == 0 {
+		writeError(writer, request, http.StatusBadRequest, "A query is required.")
+		return
+	}
+
+	if len(params.Query) > GraphQLMaxQueryLength {
+		writeError(writer, request, http.StatusBadRequest, fmt.Sprintf("Queries may not be longer than %d bytes.", GraphQLMaxQueryLength))
+		return
+	}
+
+	ctx := withComplexityBudget(request.Context(), GraphQLMaxComplexity)
+	response := self.schema.Exec(ctx, params.Query, params.OperationName, params.Variables)
+
+	writer.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "\t")
+
+	if e := encoder.Encode(response); e != nil {
+		writeError(writer, request, http.StatusInternalServerError, "Internal Server Error")
+		log.Printf("Could not marshal json for graphqlHandler: %#v.", e)
+	}
+}
+
+// Complexity budgets
+
+type complexityKey struct{}
+
+func withComplexityBudget(ctx context.Context, budget int64) context.Context {
+	return context.WithValue(ctx, complexityKey{}, &budget)
+}
+
+// spendComplexity deducts cost from the query's budget, failing once the
+// budget is exhausted. Resolvers run concurrently, hence the atomics.
+func spendComplexity(ctx context.Context, cost int64) error {
+	budget, ok := ctx.Value(complexityKey{}).(*int64)
+	if !ok {
+		return nil
+	}
+
+	if atomic.AddInt64(budget, -cost) < 0 {
+		return fmt.Errorf("The query is too complex; ask for fewer days or passages.")
+	}
+
+	return nil
+}
+
+// Resolvers
+
+type graphqlResolver struct {
+	servers []*CalendarServer
+	bible   orthocal.Bible
+}
+
+func (self *graphqlResolver) Jurisdictions() []*jurisdictionResolver {
+	resolvers := make([]*jurisdictionResolver, 0, len(self.servers))
+	for _, server := range self.servers {
+		resolvers = append(resolvers, &jurisdictionResolver{server})
+	}
+
+	return resolvers
+}
+
+func (self *graphqlResolver) Jurisdiction(args struct{ Slug string }) *jurisdictionResolver {
+	for _, server := range self.servers {
+		if server.jurisdiction.Slug == args.Slug {
+			return &jurisdictionResolver{server}
+		}
+	}
+
+	return nil
+}
+
+func (self *graphqlResolver) Passage(ctx context.Context, args struct{ Reference string }) (*passageResolver, error) {
+	return lookupPassage(ctx, self.bible, args.Reference)
+}
+
+type jurisdictionResolver struct {
+	server *CalendarServer
+}
+
+func (self *jurisdictionResolver) Slug() string {
+	return self.server.jurisdiction.Slug
+}
+
+func (self *jurisdictionResolver) Title() string {
+	return self.server.jurisdiction.Title
+}
+
+func (self *jurisdictionResolver) Julian() bool {
+	return self.server.jurisdiction.UseJulian
+}
+
+func (self *jurisdictionResolver) LukanJump() bool {
+	return self.server.jurisdiction.DoJump
+}
+
+func (self *jurisdictionResolver) Today(ctx context.Context, args struct{ Tz *string }) (*dayResolver, error) {
+	today, e := graphqlToday(args.Tz)
+	if e != nil {
+		return nil, e
+	}
+
+	return self.newDay(ctx, today)
+}
+
+func (self *jurisdictionResolver) Day(ctx context.Context, args struct{ Date string }) (*dayResolver, error) {
+	date, e := graphqlDate(args.Date)
+	if e != nil {
+		return nil, e
+	}
+
+	return self.newDay(ctx, date)
+}
+
+func (self *jurisdictionResolver) Days(ctx context.Context, args struct {
+	Start *string
+	Count int32
+	Tz    *string
+}) ([]*dayResolver, error) {
+	if args.Count < 1 || args.Count > GraphQLMaxDays {
+		return nil, fmt.Errorf("The count must be between 1 and %d.", GraphQLMaxDays)
+	}
+
+	var start time.Time
+	var e error
+	if args.Start != nil {
+		start, e = graphqlDate(*args.Start)
+	} else {
+		start, e = graphqlToday(args.Tz)
+	}
+	if e != nil {
+		return nil, e
+	}
+
+	days := make([]*dayResolver, 0, args.Count)
+	for i := 0; i < int(args.Count); i++ {
+		day, e := self.newDay(ctx, start.AddDate(0, 0, i))
+		if e != nil {
+			return nil, e
+		}
+		days = append(days, day)
+	}
+
+	return days, nil
+}
+
+// newDay generates a day without passages; those are looked up only if the
+// query asks for them.
+func (self *jurisdictionResolver) newDay(ctx context.Context, date time.Time) (*dayResolver, error) {
+	if e := checkYear(date.Year()); e != nil {
+		return nil, e
+	}
+
+	if e := spendComplexity(ctx, GraphQLDayCost); e != nil {
+		return nil, e
+	}
+
+	factory := self.server.newDayFactory()
+	day := factory.NewDayWithContext(ctx, date.Year(), int(date.Month()), date.Day(), nil)
+
+	return &dayResolver{day: day, bible: self.server.bible, jurisdiction: self.server.jurisdiction}, nil
+}
+
+func graphqlDate(value string) (time.Time, error) {
+	date, e := time.Parse("2006-01-02", value)
+	if e != nil {
+		return date, fmt.Errorf("'%s' is not formatted as YYYY-MM-DD.", value)
+	}
+
+	return date, nil
+}
+
+func graphqlToday(tzName *string) (time.Time, error) {
+	tz := TZ
+	if tzName != nil {
+		var e error
+		if tz, e = LoadLocation(*tzName); e != nil {
+			return time.Time{}, e
+		}
+	}
+
+	return Today(tz), nil
+}
+
+type dayResolver struct {
+	day          *orthocal.Day
+	bible        orthocal.Bible
+	jurisdiction Jurisdiction
+}
+
+func (self *dayResolver) Date() string {
+	return dayDate(self.day).Format("2006-01-02")
+}
+
+func (self *dayResolver) OldStyleDate() *string {
+	if !self.jurisdiction.UseJulian {
+		return nil
+	}
+
+	date := formatOldStyleDate(dayDate(self.day))
+	return &date
+}
+
+func (self *dayResolver) Weekday() string {
+	return dayDate(self.day).Weekday().String()
+}
+
+func (self *dayResolver) Titles() []string {
+	return nonNil(self.day.Titles)
+}
+
+func (self *dayResolver) Feasts() []string {
+	return nonNil(self.day.Feasts)
+}
+
+func (self *dayResolver) Saints() []string {
+	return nonNil(self.day.Saints)
+}
+
+func (self *dayResolver) Commemorations() []*commemorationResolver {
+	var resolvers []*commemorationResolver
+	for _, feast := range self.day.Feasts {
+		resolvers = append(resolvers, &commemorationResolver{Kind: "feast", Name: feast})
+	}
+	for _, saint := range self.day.Saints {
+		resolvers = append(resolvers, &commemorationResolver{Kind: "saint", Name: saint})
+	}
+
+	return resolvers
+}
+
+func (self *dayResolver) FastLevel() int32 {
+	return int32(self.day.FastLevel)
+}
+
+func (self *dayResolver) FastLevelDesc() string {
+	return self.day.FastLevelDesc
+}
+
+func (self *dayResolver) FastExceptionDesc() string {
+	return self.day.FastExceptionDesc
+}
+
+func (self *dayResolver) Readings() []*readingResolver {
+	resolvers := make([]*readingResolver, 0, len(self.day.Readings))
+	for _, reading := range self.day.Readings {
+		resolvers = append(resolvers, &readingResolver{reading: reading, bible: self.bible})
+	}
+
+	return resolvers
+}
+
+type commemorationResolver struct {
+	Kind string
+	Name string
+}
+
+type readingResolver struct {
+	reading orthocal.Reading
+	bible   orthocal.Bible
+}
+
+func (self *readingResolver) Source() string {
+	return self.reading.Source
+}
+
+func (self *readingResolver) Book() string {
+	return self.reading.Book
+}
+
+func (self *readingResolver) Description() string {
+	return self.reading.Description
+}
+
+func (self *readingResolver) Display() string {
+	return self.reading.Display
+}
+
+func (self *readingResolver) References() []*scriptureRangeResolver {
+	var resolvers []*scriptureRangeResolver
+	for _, r := range ParseReference(self.reading.Display) {
+		resolvers = append(resolvers, &scriptureRangeResolver{r})
+	}
+
+	return resolvers
+}
+
+func (self *readingResolver) Passage(ctx context.Context) (*passageResolver, error) {
+	return lookupPassage(ctx, self.bible, self.reading.Display)
+}
+
+type scriptureRangeResolver struct {
+	r ScriptureRange
+}
+
+func (self *scriptureRangeResolver) Book() string {
+	return self.r.Book
+}
+
+func (self *scriptureRangeResolver) StartChapter() int32 {
+	return int32(self.r.StartChapter)
+}
+
+func (self *scriptureRangeResolver) StartVerse() int32 {
+	return int32(self.r.StartVerse)
+}
+
+func (self *scriptureRangeResolver) EndChapter() int32 {
+	return int32(self.r.EndChapter)
+}
+
+func (self *scriptureRangeResolver) EndVerse() int32 {
+	return int32(self.r.EndVerse)
+}
+
+// lookupPassage returns nil if the passage can't be found.
+func lookupPassage(ctx context.Context, bible orthocal.Bible, reference string) (*passageResolver, error) {
+	if bible == nil {
+		return nil, nil
+	}
+
+	if e := spendComplexity(ctx, GraphQLPassageCost); e != nil {
+		return nil, e
+	}
+
+	passage := bible.Lookup(reference)
+	if len(passage) == 0 {
+		return nil, nil
+	}
+
+	return &passageResolver{NewPassageResponse(reference, passage, false)}, nil
+}
+
+type passageResolver struct {
+	passage PassageResponse
+}
+
+func (self *passageResolver) Reference() string {
+	return self.passage.Reference
+}
+
+func (self *passageResolver) Verses() []*verseResolver {
+	resolvers := make([]*verseResolver, 0, len(self.passage.Verses))
+	for _, verse := range self.passage.Verses {
+		resolvers = append(resolvers, &verseResolver{verse})
+	}
+
+	return resolvers
+}
+
+type verseResolver struct {
+	verse VerseResponse
+}
+
+func (self *verseResolver) Book() string {
+	return self.verse.Book
+}
+
+func (self *verseResolver) Chapter() int32 {
+	return int32(self.verse.Chapter)
+}
+
+func (self *verseResolver) Verse() int32 {
+	return int32(self.verse.Verse)
+}
+
+func (self *verseResolver) Text() string {
+	return strings.TrimSpace(self.verse.Text)
+}
+
+func (self *verseResolver) ParagraphStart() bool {
+	return self.verse.Paragraph
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestGraphQL(t *testing.T) {
+	router, _ := newTestRouter(t, openTestCalendar(t), nil)
+
+	tests := []struct {
+		name   string
+		query  string
+		status int
+		errors bool
+	}{
+		{"jurisdictions", `{ jurisdictions { slug julian } }`, http.StatusOK, false},
+		{"day", `{ jurisdiction(slug: "oca") { day(date: "2025-04-20") { date weekday } } }`, http.StatusOK, false},
+		{"bad date", `{ jurisdiction(slug: "oca") { day(date: "April 20") { date } } }`, http.StatusOK, true},
+		{"too many days", `{ jurisdiction(slug: "oca") { days(start: "2025-01-01", count: 1000) { date } } }`, http.StatusOK, true},
+		{"empty", ``, http.StatusBadRequest, false},
+		{"too long", `{ jurisdictions { slug } }` + strings.Repeat(" ", GraphQLMaxQueryLength), http.StatusBadRequest, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", "/graphql?query="+url.QueryEscape(tt.query), nil)
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.status {
+				t.Fatalf("Expected status %d, got %d: %s", tt.status, recorder.Code, recorder.Body.String())
+			}
+
+			if tt.status != http.StatusOK {
+				return
+			}
+
+			var response struct {
+				Data   json.RawMessage
+				Errors []interface{}
+			}
+			if e := json.Unmarshal(recorder.Body.Bytes(), &response); e != nil {
+				t.Fatalf("Could not unmarshal response: %v", e)
+			}
+
+			if (len(response.Errors) > 0) != tt.errors {
+				t.Errorf("Expected errors to be %v, got %v", tt.errors, response.Errors)
+			}
+		})
+	}
+}
+
+func TestSpendComplexity(t *testing.T) {
+	ctx := withComplexityBudget(context.Background(), GraphQLPassageCost+GraphQLDayCost)
+
+	if e := spendComplexity(ctx, GraphQLPassageCost); e != nil {
+		t.Fatalf("A passage should be within the budget: %v", e)
+	}
+	if e := spendComplexity(ctx, GraphQLDayCost); e != nil {
+		t.Fatalf("A day should be within the budget: %v", e)
+	}
+	if e := spendComplexity(ctx, GraphQLDayCost); e == nil {
+		t.Errorf("Another day should exceed the budget")
+	}
+
+	// Without a budget, nothing is limited.
+	if e := spendComplexity(context.Background(), GraphQLMaxComplexity+1); e != nil {
+		t.Errorf("Spending without a budget should succeed: %v", e)
+	}
+}
+
+func TestGraphQLComplexity(t *testing.T) {
+	router, _ := newTestRouter(t, openTestCalendar(t), nil)
+
+	// Each jurisdiction alias spends a month's worth of days.
+	var aliases []string
+	for i := 0; i < GraphQLMaxComplexity/GraphQLMaxDays+1; i++ {
+		aliases = append(aliases, `j`+strings.Repeat("x", i)+`: jurisdiction(slug: "oca") { days(start: "2025-01-01", count: 31) { date } }`)
+	}
+	query := "{ " + strings.Join(aliases, " ") + " }"
+
+	body, _ := json.Marshal(map[string]string{"query": query})
+	request := httptest.NewRequest("POST", "/graphql", strings.NewReader(string(body)))
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	if !strings.Contains(recorder.Body.String(), "too complex") {
+		t.Errorf("Expected the query to exceed its complexity budget: %.500s", recorder.Body.String())
+	}
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"context"
+	"github.com/brianglass/orthocal"
+	"github.com/brianglass/orthocal-service/orthocalpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+const DefaultGRPCPort = "9090"
+
+// GRPCServer serves the Calendar service defined in orthocalpb/orthocal.proto
+// from the same calendar servers and bible as the REST API.
+type GRPCServer struct {
+	orthocalpb.UnimplementedCalendarServer
+
+	servers []*CalendarServer
+	bible   orthocal.Bible
+}
+
+func NewGRPCServer(server *grpc.Server, servers []*CalendarServer, bible orthocal.Bible) *GRPCServer {
+	var self GRPCServer
+
+	self.servers = servers
+	self.bible = bible
+
+	orthocalpb.RegisterCalendarServer(server, &self)
+
+	return &self
+}
+
+func (self *GRPCServer) GetDay(ctx context.Context, request *orthocalpb.GetDayRequest) (*orthocalpb.Day, error) {
+	calendar, e := self.lookup(request.Jurisdiction)
+	if e != nil {
+		return nil, e
+	}
+
+	date, e := grpcDate(request.Date)
+	if e != nil {
+		return nil, e
+	}
+
+	factory := calendar.newDayFactory()
+	day := factory.NewDayWithContext(ctx, date.Year(), int(date.Month()), date.Day(), calendar.grpcBible(request.IncludePassages))
+
+	return NewGRPCDay(day, calendar.jurisdiction), nil
+}
+
+func (self *GRPCServer) ListDays(request *orthocalpb.ListDaysRequest, stream orthocalpb.Calendar_ListDaysServer) error {
+	calendar, e := self.lookup(request.Jurisdiction)
+	if e != nil {
+		return e
+	}
+
+	start, e := grpcDate(request.Start)
+	if e != nil {
+		return e
+	}
+
+	end, e := grpcDate(request.End)
+	if e != nil {
+		return e
+	}
+
+	if end.Before(start) {
+		return status.Error(codes.InvalidArgument, "The end date must not be before the start date.")
+	}
+
+	if days := int(end.Sub(start).Hours()/24) + 1; days > RangeMaxDays {
+		return status.Errorf(codes.InvalidArgument, "The range may not span more than %d days.", RangeMaxDays)
+	}
+
+	ctx := stream.Context()
+	factory := calendar.newDayFactory()
+	bible := calendar.grpcBible(request.IncludePassages)
+
+	for date := start; !date.After(end); date = date.AddDate(0, 0, 1) {
+		if e := ctx.Err(); e != nil {
+			return status.FromContextError(e).Err()
+		}
+
+		day := factory.NewDayWithContext(ctx, date.Year(), int(date.Month()), date.Day(), bible)
+		if e := stream.Send(NewGRPCDay(day, calendar.jurisdiction)); e != nil {
+			return e
+		}
+	}
+
+	return nil
+}
+
+func (self *GRPCServer) GetPassage(ctx context.Context, request *orthocalpb.GetPassageRequest) (*orthocalpb.Passage, error) {
+	if len(request.Reference) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "A reference is required.")
+	}
+
+	if self.bible == nil {
+		return nil, status.Error(codes.Unavailable, "No bible is configured.")
+	}
+
+	passage := self.bible.Lookup(request.Reference)
+	if len(passage) == 0 {
+		return nil, status.Errorf(codes.NotFound, "No passage was found for '%s'.", request.Reference)
+	}
+
+	response := NewPassageResponse(request.Reference, passage, false)
+
+	p := &orthocalpb.Passage{Reference: response.Reference}
+	for _, verse := range response.Verses {
+		p.Verses = append(p.Verses, &orthocalpb.Verse{
+			Book:           verse.Book,
+			Chapter:        int32(verse.Chapter),
+			Verse:          int32(verse.Verse),
+			Text:           verse.Text,
+			ParagraphStart: verse.Paragraph,
+		})
+	}
+
+	return p, nil
+}
+
+func (self *GRPCServer) ListJurisdictions(ctx context.Context, request *orthocalpb.ListJurisdictionsRequest) (*orthocalpb.ListJurisdictionsResponse, error) {
+	var response orthocalpb.ListJurisdictionsResponse
+
+	for _, server := range self.servers {
+		response.Jurisdictions = append(response.Jurisdictions, &orthocalpb.Jurisdiction{
+			Slug:      server.jurisdiction.Slug,
+			Title:     server.jurisdiction.Title,
+			Julian:    server.jurisdiction.UseJulian,
+			LukanJump: server.jurisdiction.DoJump,
+		})
+	}
+
+	return &response, nil
+}
+
+func (self *GRPCServer) lookup(slug string) (*CalendarServer, error) {
+	for _, server := range self.servers {
+		if server.jurisdiction.Slug == slug {
+			return server, nil
+		}
+	}
+
+	return nil, status.Errorf(codes.NotFound, "There is no jurisdiction named '%s'.", slug)
+}
+
+// grpcBible is the gRPC equivalent of requestBible.
+func (self *CalendarServer) grpcBible(include bool) orthocal.Bible {
+	if !include {
+		return nil
+	}
+
+	return self.bible
+}
+
+// grpcDate validates a requested date the same way validDate does for the
+// REST API.
+func grpcDate(d *orthocalpb.Date) (time.Time, error) {
+	if d == nil {
+		return time.Time{}, status.Error(codes.InvalidArgument, "A date is required.")
+	}
+
+	date := time.Date(int(d.Year), time.Month(d.Month), int(d.Day), 0, 0, 0, 0, time.UTC)
+	if date.Year() != int(d.Year) || date.Month() != time.Month(d.Month) || date.Day() != int(d.Day) {
+		return date, status.Errorf(codes.InvalidArgument, "%04d-%02d-%02d is not a valid date.", d.Year, d.Month, d.Day)
+	}
+
+	if e := checkYear(date.Year()); e != nil {
+		return date, status.Error(codes.OutOfRange, e.Error())
+	}
+
+	return date, nil
+}
+
+// NewGRPCDay converts an orthocal.Day by way of the v2 response types so the
+// two APIs describe days the same way.
+func NewGRPCDay(day *orthocal.Day, jurisdiction Jurisdiction) *orthocalpb.Day {
+	v := NewV2Day(day, jurisdiction)
+	date := dayDate(day)
+
+	d := &orthocalpb.Day{
+		Date: &orthocalpb.Date{
+			Year:  int32(date.Year()),
+			Month: int32(date.Month()),
+			Day:   int32(date.Day()),
+		},
+		Weekday:      v.Weekday,
+		Jurisdiction: v.Jurisdiction,
+		Titles:       v.Titles,
+		Commemorations: &orthocalpb.Commemorations{
+			Feasts: v.Commemorations.Feasts,
+			Saints: v.Commemorations.Saints,
+		},
+		Fasting: &orthocalpb.Fasting{
+			Level:       int32(v.Fasting.Level),
+			Description: v.Fasting.Description,
+			Exception:   v.Fasting.Exception,
+		},
+	}
+
+	if jurisdiction.UseJulian {
+		y, m, dd := OldStyleDate(date)
+		d.OldStyleDate = &orthocalpb.Date{
+			Year:  int32(y),
+			Month: int32(m),
+			Day:   int32(dd),
+		}
+	}
+
+	for i, reading := range v.Readings {
+		r := &orthocalpb.Reading{
+			Source:      reading.Source,
+			Book:        reading.Book,
+			Description: reading.Description,
+			Display:     reading.Display,
+		}
+
+		for _, ref := range reading.References {
+			r.References = append(r.References, &orthocalpb.ScriptureRange{
+				Book:         ref.Book,
+				StartChapter: int32(ref.StartChapter),
+				StartVerse:   int32(ref.StartVerse),
+				EndChapter:   int32(ref.EndChapter),
+				EndVerse:     int32(ref.EndVerse),
+			})
+		}
+
+		// The v2 verses don't name their book and a reading can span books,
+		// so the verses come from the original passage as in GetPassage.
+		passage := NewPassageResponse(reading.Display, day.Readings[i].Passage, false)
+		for _, verse := range passage.Verses {
+			r.Passage = append(r.Passage, &orthocalpb.Verse{
+				Book:           verse.Book,
+				Chapter:        int32(verse.Chapter),
+				Verse:          int32(verse.Verse),
+				Text:           verse.Text,
+				ParagraphStart: verse.Paragraph,
+			})
+		}
+
+		d.Readings = append(d.Readings, r)
+	}
+
+	return d
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"github.com/brianglass/orthocal"
+	"github.com/brianglass/orthocal-service/orthocalpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+func TestGRPCDate(t *testing.T) {
+	tests := []struct {
+		date *orthocalpb.Date
+		code codes.Code
+	}{
+		{&orthocalpb.Date{Year: 2025, Month: 4, Day: 20}, codes.OK},
+		{&orthocalpb.Date{Year: 2024, Month: 2, Day: 29}, codes.OK},
+		{&orthocalpb.Date{Year: 2025, Month: 2, Day: 29}, codes.InvalidArgument},
+		{&orthocalpb.Date{Year: 2025, Month: 13, Day: 1}, codes.InvalidArgument},
+		{&orthocalpb.Date{Year: 1800, Month: 1, Day: 1}, codes.OutOfRange},
+		{nil, codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		_, e := grpcDate(tt.date)
+		if code := status.Code(e); code != tt.code {
+			t.Errorf("grpcDate(%v): expected %v, got %v", tt.date, tt.code, code)
+		}
+	}
+}
+
+func TestGRPCJurisdictions(t *testing.T) {
+	config, e := LoadConfig(DefaultConfigPath)
+	if e != nil {
+		t.Fatalf("Could not load %s: %v", DefaultConfigPath, e)
+	}
+
+	var servers []*CalendarServer
+	for _, jurisdiction := range config.Jurisdictions {
+		servers = append(servers, &CalendarServer{jurisdiction: jurisdiction})
+	}
+
+	server := GRPCServer{servers: servers}
+
+	response, e := server.ListJurisdictions(context.Background(), &orthocalpb.ListJurisdictionsRequest{})
+	if e != nil {
+		t.Fatalf("ListJurisdictions: %v", e)
+	}
+
+	if len(response.Jurisdictions) != len(config.Jurisdictions) {
+		t.Errorf("Expected %d jurisdictions, got %d", len(config.Jurisdictions), len(response.Jurisdictions))
+	}
+
+	_, e = server.GetDay(context.Background(), &orthocalpb.GetDayRequest{Jurisdiction: "nonesuch"})
+	if code := status.Code(e); code != codes.NotFound {
+		t.Errorf("Expected NotFound for an unknown jurisdiction, got %v", code)
+	}
+}
+
+func TestNewGRPCDayVerseBooks(t *testing.T) {
+	day := &orthocal.Day{
+		Year:  2025,
+		Month: 4,
+		Day:   20,
+		Readings: []orthocal.Reading{
+			{
+				Book:    "Gen",
+				Display: "Gen 50.26-Exod 1.1",
+				Passage: orthocal.Passage{
+					{Book: "Gen", Chapter: 50, Verse: 26, Content: "So Joseph died."},
+					{Book: "Exod", Chapter: 1, Verse: 1, Content: "Now these are the names."},
+				},
+			},
+		},
+	}
+
+	d := NewGRPCDay(day, Jurisdiction{Slug: "oca"})
+
+	var books []string
+	for _, verse := range d.Readings[0].Passage {
+		books = append(books, verse.Book)
+	}
+
+	if len(books) != 2 || books[0] != "Gen" || books[1] != "Exod" {
+		t.Errorf("Expected the verses to be from Gen and Exod, got %v", books)
+	}
+}
@@ -17,9 +17,19 @@ const (
 	WebBaseURL        = "https://orthocal.info"
 )
 
-func GenerateCalendar(ctx context.Context, writer io.Writer, start time.Time, numDays int, factory *orthocal.DayFactory, title string) {
-	today := time.Now().In(TZ)
+func GenerateCalendar(ctx context.Context, writer io.Writer, start time.Time, numDays int, factory *orthocal.DayFactory, jurisdiction Jurisdiction, tz *time.Location, stamp time.Time) {
+	writeCalendarHeader(writer, jurisdiction.Title, tz)
 
+	for i := 0; i < numDays; i++ {
+		date := start.AddDate(0, 0, i)
+		day := factory.NewDayWithContext(ctx, date.Year(), int(date.Month()), date.Day(), nil)
+		writeCalendarEvent(writer, day, jurisdiction, stamp)
+	}
+
+	fmt.Fprintf(writer, "END:VCALENDAR")
+}
+
+func writeCalendarHeader(writer io.Writer, title string, tz *time.Location) {
 	fmt.Fprintf(writer, "BEGIN:VCALENDAR\r\n")
 	fmt.Fprintf(writer, "PRODID:-//brianglass//Orthocal//en\r\n")
 	fmt.Fprintf(writer, "VERSION:2.0\r\n")
@@ -27,31 +37,33 @@ func GenerateCalendar(ctx context.Context, writer io.Writer, start time.Time, nu
 	fmt.Fprintf(writer, "X-WR-CALNAME:%s (%s)\r\n", CalendarName, title)
 	fmt.Fprintf(writer, "REFRESH-INTERVAL;VALUE=DURATION:PT%dH\r\n", CalendarTTL)
 	fmt.Fprintf(writer, "X-PUBLISHED-TTL:PT%dH\r\n", CalendarTTL)
-	fmt.Fprintf(writer, "TIMEZONE-ID:%s\r\n", TimeZone)
-	fmt.Fprintf(writer, "X-WR-TIMEZONE:%s\r\n", TimeZone)
-
-	for i := 0; i < numDays; i++ {
-		date := start.AddDate(0, 0, i)
-		day := factory.NewDayWithContext(ctx, date.Year(), int(date.Month()), date.Day(), nil)
-		uid := fmt.Sprintf("%s.%s@orthocal.info", date.Format("2006-01-02"), title)
-
-		fmt.Fprintf(writer, "BEGIN:VEVENT\r\n")
-		fmt.Fprintf(writer, "UID:%s\r\n", uid)
-		fmt.Fprintf(writer, "DTSTAMP:%s\r\n", today.Format("20060102T150405Z"))
-		fmt.Fprintf(writer, "DTSTART:%s\r\n", date.Format("20060102"))
-		fmt.Fprintf(writer, "SUMMARY:%s\r\n", strings.Join(day.Titles, "; "))
-		fmt.Fprintf(writer, "DESCRIPTION:%s\r\n", icalDescription(day))
-		fmt.Fprintf(writer, "URL:%s/calendar/%s/%d/%d/%d\r\n", WebBaseURL, strings.ToLower(title), date.Year(), int(date.Month()), date.Day())
-		fmt.Fprintf(writer, "CLASS:PUBLIC\r\n")
-		fmt.Fprintf(writer, "END:VEVENT\r\n")
-	}
+	fmt.Fprintf(writer, "TIMEZONE-ID:%s\r\n", tz)
+	fmt.Fprintf(writer, "X-WR-TIMEZONE:%s\r\n", tz)
+}
 
-	fmt.Fprintf(writer, "END:VCALENDAR")
+func writeCalendarEvent(writer io.Writer, day *orthocal.Day, jurisdiction Jurisdiction, stamp time.Time) {
+	date := time.Date(day.Year, time.Month(day.Month), day.Day, 0, 0, 0, 0, time.UTC)
+	uid := fmt.Sprintf("%s.%s@orthocal.info", date.Format("2006-01-02"), jurisdiction.Title)
+
+	fmt.Fprintf(writer, "BEGIN:VEVENT\r\n")
+	fmt.Fprintf(writer, "UID:%s\r\n", uid)
+	fmt.Fprintf(writer, "DTSTAMP:%s\r\n", stamp.UTC().Format("20060102T150405Z"))
+	fmt.Fprintf(writer, "DTSTART:%s\r\n", date.Format("20060102"))
+	fmt.Fprintf(writer, "SUMMARY:%s\r\n", strings.Join(day.Titles, "; "))
+	fmt.Fprintf(writer, "DESCRIPTION:%s\r\n", icalDescription(day, jurisdiction))
+	fmt.Fprintf(writer, "URL:%s/%d/%d/%d\r\n", jurisdiction.WebURL, date.Year(), int(date.Month()), date.Day())
+	fmt.Fprintf(writer, "CLASS:PUBLIC\r\n")
+	fmt.Fprintf(writer, "END:VEVENT\r\n")
 }
 
-func icalDescription(day *orthocal.Day) string {
+func icalDescription(day *orthocal.Day, jurisdiction Jurisdiction) string {
 	var s string
 
+	if jurisdiction.UseJulian {
+		_, month, d := OldStyleDate(dayDate(day))
+		s += fmt.Sprintf(`%s %d old style\n\n`, time.Month(month), d)
+	}
+
 	feasts := strings.Join(day.Feasts, "; ")
 	if len(feasts) > 0 {
 		s += feasts + `\n\n`
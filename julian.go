@@ -0,0 +1,137 @@
+package main
+
+import "time"
+
+// These conversions go through the Julian Day Number rather than adding a
+// fixed offset, since the difference between the Julian and Gregorian
+// calendars grows by a day in each century year not divisible by 400.
+
+// GregorianToJDN returns the Julian Day Number of a Gregorian calendar date.
+func GregorianToJDN(year, month, day int) int {
+	a := (14 - month) / 12
+	y := year + 4800 - a
+	m := month + 12*a - 3
+
+	return day + (153*m+2)/5 + 365*y + y/4 - y/100 + y/400 - 32045
+}
+
+// JulianToJDN returns the Julian Day Number of a Julian calendar date.
+func JulianToJDN(year, month, day int) int {
+	a := (14 - month) / 12
+	y := year + 4800 - a
+	m := month + 12*a - 3
+
+	return day + (153*m+2)/5 + 365*y + y/4 - 32083
+}
+
+// JDNToGregorian returns the Gregorian calendar date of a Julian Day Number.
+func JDNToGregorian(jdn int) (year, month, day int) {
+	a := jdn + 32044
+	b := (4*a + 3) / 146097
+	c := a - 146097*b/4
+	d := (4*c + 3) / 1461
+	e := c - 1461*d/4
+	m := (5*e + 2) / 153
+
+	day = e - (153*m+2)/5 + 1
+	month = m + 3 - 12*(m/10)
+	year = 100*b + d - 4800 + m/10
+
+	return year, month, day
+}
+
+// JDNToJulian returns the Julian calendar date of a Julian Day Number.
+func JDNToJulian(jdn int) (year, month, day int) {
+	c := jdn + 32082
+	d := (4*c + 3) / 1461
+	e := c - 1461*d/4
+	m := (5*e + 2) / 153
+
+	day = e - (153*m+2)/5 + 1
+	month = m + 3 - 12*(m/10)
+	year = d - 4800 + m/10
+
+	return year, month, day
+}
+
+// JulianDate returns the civil (Gregorian) date, at midnight UTC, that falls
+// on the given Julian calendar date.
+func JulianDate(year, month, day int) time.Time {
+	y, m, d := JDNToGregorian(JulianToJDN(year, month, day))
+	return time.Date(y, time.Month(m), d, 0, 0, 0, 0, time.UTC)
+}
+
+// ComputePascha returns the civil date of Orthodox Pascha in a year. Pascha
+// is computed on the Julian calendar by every Orthodox jurisdiction,
+// including those that follow the Revised Julian calendar for fixed feasts.
+func ComputePascha(year int) time.Time {
+	// Meeus's Julian algorithm
+	a := year % 4
+	b := year % 7
+	c := year % 19
+	d := (19*c + 15) % 30
+	e := (2*a + 4*b - d + 34) % 7
+	month := (d + e + 114) / 31
+	day := (d+e+114)%31 + 1
+
+	return JulianDate(year, month, day)
+}
+
+// FixedFeastDate returns the civil date of a fixed feast kept on month and
+// day of the given year of a jurisdiction's calendar. Through the year 2799
+// the Revised Julian calendar matches the Gregorian calendar.
+func FixedFeastDate(useJulian bool, year, month, day int) time.Time {
+	if useJulian {
+		return JulianDate(year, month, day)
+	}
+
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+const (
+	GregorianCalendar = "gregorian"
+	JulianCalendar    = "julian"
+)
+
+var (
+	calendarToJDN = map[string]func(year, month, day int) int{
+		GregorianCalendar: GregorianToJDN,
+		JulianCalendar:    JulianToJDN,
+	}
+
+	calendarFromJDN = map[string]func(jdn int) (year, month, day int){
+		GregorianCalendar: JDNToGregorian,
+		JulianCalendar:    JDNToJulian,
+	}
+)
+
+// ConvertDate converts a date from one calendar to another. Ok is false if
+// either calendar is unknown or the date doesn't exist in the from calendar,
+// as with February 29, 1900, which is only a Julian date.
+func ConvertDate(year, month, day int, from, to string) (y, m, d int, ok bool) {
+	toJDN, ok := calendarToJDN[from]
+	if !ok {
+		return 0, 0, 0, false
+	}
+
+	fromJDN, ok := calendarFromJDN[to]
+	if !ok {
+		return 0, 0, 0, false
+	}
+
+	jdn := toJDN(year, month, day)
+
+	// The formulas happily accept out of range months and days, so check
+	// that the date survives the round trip.
+	if y, m, d := calendarFromJDN[from](jdn); y != year || m != month || d != day {
+		return 0, 0, 0, false
+	}
+
+	y, m, d = fromJDN(jdn)
+	return y, m, d, true
+}
+
+// OldStyleDate returns the Julian calendar date of a civil date.
+func OldStyleDate(date time.Time) (year, month, day int) {
+	return JDNToJulian(GregorianToJDN(date.Year(), int(date.Month()), date.Day()))
+}
@@ -4,13 +4,17 @@ import (
 	"database/sql"
 	"github.com/brianglass/english_bible"
 	alexa "github.com/brianglass/go-alexa/skillserver"
+	"github.com/brianglass/orthocal"
 	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/rs/cors"
+	"google.golang.org/grpc"
 	"log"
+	"net"
 	"net/http"
 	"os"
+	"strconv"
 	"time"
 )
 
@@ -23,8 +27,9 @@ const (
 )
 
 var (
-	TZ         *time.Location
-	AlexaAppId = os.Getenv("ALEXA_APP_ID")
+	TZ           *time.Location
+	AlexaAppId   = os.Getenv("ALEXA_APP_ID")
+	RangeMaxDays = DefaultRangeMaxDays
 )
 
 func init() {
@@ -34,27 +39,63 @@ func init() {
 		TZ = time.UTC
 		log.Printf("Error loading '%s' timezone, using UTC.", TimeZone)
 	}
+
+	if value := os.Getenv("RANGE_MAX_DAYS"); len(value) > 0 {
+		if n, e := strconv.Atoi(value); e == nil && n > 0 {
+			RangeMaxDays = n
+		} else {
+			log.Printf("Invalid RANGE_MAX_DAYS '%s', using %d.", value, RangeMaxDays)
+		}
+	}
 }
 
 func main() {
-	var ocadb, bibledb *sql.DB
+	var calendardb *sql.DB
 	var e error
 
-	// Open up all the requisite databases
+	configPath := os.Getenv("JURISDICTIONS_CONFIG")
+	if len(configPath) == 0 {
+		configPath = DefaultConfigPath
+	}
 
-	if ocadb, e = sql.Open("sqlite3", "oca_calendar.db"); e != nil {
-		log.Printf("Got error opening database: %#v. Exiting.", e)
+	config, e := LoadConfig(configPath)
+	if e != nil {
+		log.Printf("Got error loading configuration: %#v. Exiting.", e)
 		os.Exit(1)
 	}
-	defer ocadb.Close()
 
-	if bibledb, e = sql.Open("sqlite3", "english.db"); e != nil {
+	FirstYear, LastYear = config.FirstYear, config.LastYear
+
+	// Open up all the requisite databases
+
+	if calendardb, e = sql.Open("sqlite3", config.CalendarDB); e != nil {
 		log.Printf("Got error opening database: %#v. Exiting.", e)
 		os.Exit(1)
 	}
-	defer bibledb.Close()
+	defer calendardb.Close()
+
+	dataFiles := []string{config.CalendarDB}
+	bibles := make(map[string]orthocal.Bible)
+	for name, path := range config.Bibles {
+		bibledb, e := sql.Open("sqlite3", path)
+		if e != nil {
+			log.Printf("Got error opening database: %#v. Exiting.", e)
+			os.Exit(1)
+		}
+		defer bibledb.Close()
+
+		bibles[name] = english_bible.NewBible(bibledb)
+		dataFiles = append(dataFiles, path)
+	}
 
-	bible := english_bible.NewBible(bibledb)
+	// Responses are cached by clients based on the data we serve, so
+	// changing either the databases or the binary needs to invalidate them.
+	if executable, e := os.Executable(); e == nil {
+		dataFiles = append(dataFiles, executable)
+	}
+	if e := SetDataVersion(dataFiles...); e != nil {
+		log.Printf("Could not determine the data version: %#v.", e)
+	}
 
 	// Setup HTTP routers
 
@@ -64,30 +105,77 @@ func main() {
 	router.HandleFunc("/", healthHandler)
 	router.HandleFunc("/healthz", healthHandler)
 
-	ocaRouter := router.PathPrefix("/api/oca").Subrouter()
-	NewCalendarServer(ocaRouter, ocadb, false, true, bible, "OCA")
-
-	rocorRouter := router.PathPrefix("/api/rocor").Subrouter()
-	NewCalendarServer(rocorRouter, ocadb, true, true, bible, "ROCOR") // Apparently Rocor now does the Lukan jump
+	servers := mountAPI(router, config, calendardb, bibles)
 
 	// Setup Alexa skill
 
-	apps := map[string]interface{}{
-		"/echo/": NewSkill(AlexaAppId, ocadb, false, true, bible, TZ),
+	if jurisdiction := config.Jurisdiction(config.Alexa); jurisdiction != nil {
+		apps := map[string]interface{}{
+			"/echo/": NewSkill(AlexaAppId, calendardb, *jurisdiction, bibles[jurisdiction.Bible], TZ),
+		}
+		alexa.Init(apps, router.NewRoute().Subrouter())
 	}
-	alexa.Init(apps, router.NewRoute().Subrouter())
 
 	// Setup middleware
 
 	router.Use(cors.Default().Handler)
 	router.Use(handlers.CompressHandler)
+	router.Use(requestIdMiddleware)
 	router.Use(logHeaderMiddleware)
 
+	// Launch the gRPC server on its own port
+
+	grpcPort := os.Getenv("GRPC_PORT")
+	if len(grpcPort) == 0 {
+		grpcPort = DefaultGRPCPort
+	}
+
+	listener, e := net.Listen("tcp", ":"+grpcPort)
+	if e != nil {
+		log.Printf("Got error listening on gRPC port %s: %#v. Exiting.", grpcPort, e)
+		os.Exit(1)
+	}
+
+	grpcServer := grpc.NewServer()
+	NewGRPCServer(grpcServer, servers, bibles[config.DefaultBible])
+	go func() {
+		if e := grpcServer.Serve(listener); e != nil {
+			log.Printf("gRPC server stopped: %#v.", e)
+		}
+	}()
+
 	// Launch the HTTP server
 
 	http.ListenAndServe(":8080", handlers.CombinedLoggingHandler(os.Stdout, router))
 }
 
+// mountAPI mounts the HTTP API for every configured jurisdiction and returns
+// the calendar servers. Each subrouter is mounted with a trailing slash
+// because PathPrefix matches strings rather than path segments; without it
+// /api/oca would also claim /api/oca-greek.
+func mountAPI(router *mux.Router, config *Config, calendardb *sql.DB, bibles map[string]orthocal.Bible) []*CalendarServer {
+	router.MethodNotAllowedHandler = http.HandlerFunc(methodNotAllowedHandler)
+
+	var servers []*CalendarServer
+	for _, jurisdiction := range config.Jurisdictions {
+		jurisdictionRouter := router.PathPrefix("/api/" + jurisdiction.Slug + "/").Subrouter()
+		server := NewCalendarServer(jurisdictionRouter, calendardb, jurisdiction, bibles[jurisdiction.Bible])
+		servers = append(servers, server)
+
+		v2Router := router.PathPrefix("/api/v2/" + jurisdiction.Slug + "/").Subrouter()
+		NewV2CalendarServer(v2Router, server)
+	}
+
+	NewDirectory(router, servers)
+
+	bibleRouter := router.PathPrefix("/api/bible/").Subrouter()
+	NewBibleServer(bibleRouter, bibles[config.DefaultBible])
+
+	NewGraphQLServer(router, servers, bibles[config.DefaultBible])
+
+	return servers
+}
+
 func healthHandler(writer http.ResponseWriter, request *http.Request) {
 	writer.Header().Set("Content-Type", "text/plain")
 	writer.WriteHeader(http.StatusOK)
@@ -0,0 +1,518 @@
+package main
+
+import (
+	"fmt"
+	"github.com/gorilla/mux"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// The OpenAPI document is generated rather than written by hand so that it
+// can't drift from the code: paths and path parameters come from walking the
+// router, and response schemas are derived from the Go types by reflection.
+// The only hand-maintained part is operationDocs, which says what each route
+// does and returns; TestOpenAPI fails if a route is missing from it.
+
+const (
+	OpenAPIVersion = "3.0.3"
+)
+
+// Matches a mux path variable, capturing its name and pattern
+var pathParamRe = regexp.MustCompile(`\{(\w+)(?::([^}]*))?\}`)
+
+type operationDoc struct {
+	Summary     string
+	Path        []parameterDoc // Path variables that aren't integers
+	Query       []parameterDoc
+	Request     interface{} // A value of the JSON request body type, or nil
+	ContentType string
+	Response    interface{} // A value of the type returned, or nil
+
+	// Negotiated is true if the route can also respond with any of the
+	// registered day formats.
+	Negotiated bool
+
+	// Streamed is true if the route can also stream the elements of its
+	// response as newline delimited JSON.
+	Streamed bool
+}
+
+type parameterDoc struct {
+	Name        string
+	Description string
+	Type        string
+}
+
+var (
+	passagesParam = parameterDoc{"passages", "Whether to include the text of the scripture readings", "boolean"}
+	fieldsParam   = parameterDoc{"fields", "A comma separated list of the JSON fields to return, such as titles,readings.display", "string"}
+	compareAParam = parameterDoc{"a", "The slug of the first jurisdiction to compare", "string"}
+	compareBParam = parameterDoc{"b", "The slug of the second jurisdiction to compare", "string"}
+	tzParam       = parameterDoc{"tz", "An IANA time zone used to determine today; may also be given with the X-Timezone header", "string"}
+)
+
+// operationDocs describes each API route. The keys are path templates with
+// the jurisdiction replaced by {jurisdiction} and the patterns stripped from
+// path variables.
+var operationDocs = map[string]operationDoc{
+	"/api/{jurisdiction}/": {
+		Summary:     "Get today in the requested time zone",
+		Query:       []parameterDoc{passagesParam, fieldsParam, tzParam},
+		ContentType: "application/json",
+		Response:    DayResponse{},
+		Negotiated:  true,
+	},
+	"/api/{jurisdiction}/ical/": {
+		Summary:     "Get an iCal feed of the surrounding 30 weeks",
+		Query:       []parameterDoc{tzParam},
+		ContentType: "text/calendar",
+	},
+	"/api/{jurisdiction}/tomorrow/": {
+		Summary:     "Get tomorrow in the requested time zone",
+		Query:       []parameterDoc{passagesParam, fieldsParam, tzParam},
+		ContentType: "application/json",
+		Response:    DayResponse{},
+		Negotiated:  true,
+	},
+	"/api/{jurisdiction}/yesterday/": {
+		Summary:     "Get yesterday in the requested time zone",
+		Query:       []parameterDoc{passagesParam, fieldsParam, tzParam},
+		ContentType: "application/json",
+		Response:    DayResponse{},
+		Negotiated:  true,
+	},
+	"/api/{jurisdiction}/next/{n}/": {
+		Summary:     "Get the next n days, beginning with today in the requested time zone",
+		Query:       []parameterDoc{passagesParam, fieldsParam, tzParam},
+		ContentType: "application/json",
+		Response:    []DayResponse{},
+		Streamed:    true,
+	},
+	"/api/{jurisdiction}/week/": {
+		Summary:     "Get the ISO week, Monday through Sunday, containing today in the requested time zone",
+		Query:       []parameterDoc{passagesParam, fieldsParam, tzParam},
+		ContentType: "application/json",
+		Response:    []DayResponse{},
+		Streamed:    true,
+	},
+	"/api/{jurisdiction}/week/{year}/{isoweek}/": {
+		Summary:     "Get an ISO week, Monday through Sunday",
+		Query:       []parameterDoc{passagesParam, fieldsParam},
+		ContentType: "application/json",
+		Response:    []DayResponse{},
+		Streamed:    true,
+	},
+	"/api/{jurisdiction}/range/": {
+		Summary: "Get every day from start through end",
+		Query: []parameterDoc{
+			{"start", "The first day, as YYYY-MM-DD", "string"},
+			{"end", "The last day, as YYYY-MM-DD", "string"},
+			passagesParam,
+			fieldsParam,
+		},
+		ContentType: "application/json",
+		Response:    []DayResponse{},
+		Streamed:    true,
+	},
+	"/api/{jurisdiction}/days": {
+		Summary:     "Get each of a list of days, in the order requested",
+		Request:     BatchRequest{},
+		ContentType: "application/json",
+		Response:    []DayResponse{},
+	},
+	"/api/{jurisdiction}/search/": {
+		Summary: "Find the days whose titles, feasts or saints match a query",
+		Query: []parameterDoc{
+			{"q", "The words to search for, ignoring case and diacritics", "string"},
+			{"year", "The year to search; defaults to the current year", "integer"},
+			tzParam,
+		},
+		ContentType: "application/json",
+		Response:    SearchResponse{},
+	},
+	"/api/{jurisdiction}/resolve/": {
+		Summary: "Find the date described by an expression such as \"the Sunday after Theophany\"",
+		Query: []parameterDoc{
+			{"q", "The date expression, such as next Sunday, Pascha 2027 or in three days", "string"},
+			tzParam,
+		},
+		ContentType: "application/json",
+		Response:    ResolveResponse{},
+	},
+	"/api/{jurisdiction}/upcoming/": {
+		Summary: "Get the next great feasts and fasts with the days remaining until each",
+		Query: []parameterDoc{
+			{"from", "The day to count from, as YYYY-MM-DD; defaults to today", "string"},
+			{"limit", "The number of events to return", "integer"},
+			tzParam,
+		},
+		ContentType: "application/json",
+		Response:    UpcomingResponse{},
+	},
+	"/api/{jurisdiction}/paschalion/{year}/": {
+		Summary:     "Get the moveable cycle of a year",
+		ContentType: "application/json",
+		Response:    Paschalion{},
+	},
+	"/api/{jurisdiction}/fasts/{year}/": {
+		Summary:     "Get the fasting seasons and fast-free periods of a year",
+		ContentType: "application/json",
+		Response:    FastingCalendar{},
+	},
+	"/api/{jurisdiction}/{year}/": {
+		Summary:     "Get a summary of every day in a year",
+		ContentType: "application/json",
+		Response:    YearSummary{},
+	},
+	"/api/{jurisdiction}/{year}/{month}/": {
+		Summary:     "Get every day in a month",
+		Query:       []parameterDoc{passagesParam, fieldsParam},
+		ContentType: "application/json",
+		Response:    []DayResponse{},
+		Streamed:    true,
+	},
+	"/api/{jurisdiction}/{year}/{month}/{day}/": {
+		Summary:     "Get a day",
+		Query:       []parameterDoc{passagesParam, fieldsParam},
+		ContentType: "application/json",
+		Response:    DayResponse{},
+		Negotiated:  true,
+	},
+	"/api/v2/{jurisdiction}/": {
+		Summary:     "Get today in the requested time zone",
+		Query:       []parameterDoc{passagesParam, tzParam},
+		ContentType: "application/json",
+		Response:    V2Day{},
+	},
+	"/api/v2/{jurisdiction}/{year}/{month}/": {
+		Summary:     "Get every day in a month",
+		Query:       []parameterDoc{passagesParam},
+		ContentType: "application/json",
+		Response:    []V2Day{},
+	},
+	"/api/v2/{jurisdiction}/{year}/{month}/{day}/": {
+		Summary:     "Get a day",
+		Query:       []parameterDoc{passagesParam},
+		ContentType: "application/json",
+		Response:    V2Day{},
+	},
+	"/api/convert/": {
+		Summary: "Convert a date between the Gregorian and Julian calendars",
+		Query: []parameterDoc{
+			{"date", "The date to convert, as YYYY-MM-DD", "string"},
+			{"from", "The calendar of the date, gregorian (the default) or julian", "string"},
+			{"to", "The calendar to convert to, julian (the default) or gregorian", "string"},
+		},
+		ContentType: "application/json",
+		Response:    ConversionResponse{},
+	},
+	"/api/compare/{month}/": {
+		Summary:     "Compare every day in a month in two jurisdictions",
+		Path:        []parameterDoc{{"month", "The month to compare, as YYYY-MM", "string"}},
+		Query:       []parameterDoc{compareAParam, compareBParam},
+		ContentType: "application/json",
+		Response:    []Comparison{},
+	},
+	"/api/compare/{date}/": {
+		Summary:     "Compare a day in two jurisdictions",
+		Path:        []parameterDoc{{"date", "The day to compare, as YYYY-MM-DD", "string"}},
+		Query:       []parameterDoc{compareAParam, compareBParam},
+		ContentType: "application/json",
+		Response:    Comparison{},
+	},
+	"/api/bible/": {
+		Summary: "Look up a scripture passage",
+		Query: []parameterDoc{
+			{"ref", "A scripture reference, such as Matt 22.15-23.39", "string"},
+			{"markup", "Whether to keep the markup in the verse text", "boolean"},
+		},
+		ContentType: "application/json",
+		Response:    PassageResponse{},
+	},
+	"/api/jurisdictions": {
+		Summary:     "List the jurisdictions served",
+		ContentType: "application/json",
+		Response:    []JurisdictionDescription{},
+	},
+	"/api/openapi.json": {
+		Summary:     "Get this document",
+		ContentType: "application/json",
+	},
+}
+
+// BuildOpenAPI generates an OpenAPI document for every route under /api on
+// router. It returns an error if a route isn't described in operationDocs.
+func BuildOpenAPI(router *mux.Router, slugs []string) (map[string]interface{}, error) {
+	paths := make(map[string]interface{})
+	schemas := make(map[string]interface{})
+	var undocumented []string
+
+	e := router.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		template, e := route.GetPathTemplate()
+		if e != nil || !strings.HasPrefix(template, "/api/") || route.GetHandler() == nil {
+			return nil
+		}
+
+		methods, e := route.GetMethods()
+		if e != nil {
+			methods = []string{"GET"}
+		}
+
+		path, isJurisdiction := normalizePath(template, slugs)
+		doc, ok := operationDocs[path]
+		if !ok {
+			undocumented = append(undocumented, template)
+			return nil
+		}
+
+		item, _ := paths[path].(map[string]interface{})
+		if item == nil {
+			item = make(map[string]interface{})
+			paths[path] = item
+		}
+
+		for _, method := range methods {
+			if method == "HEAD" || method == "OPTIONS" {
+				continue
+			}
+
+			item[strings.ToLower(method)] = buildOperation(path, doc, isJurisdiction, slugs, schemas)
+		}
+
+		return nil
+	})
+	if e != nil {
+		return nil, e
+	}
+
+	buildSchema(reflect.TypeOf(ErrorResponse{}), schemas)
+
+	document := map[string]interface{}{
+		"openapi": OpenAPIVersion,
+		"info": map[string]interface{}{
+			"title":   "Orthocal",
+			"version": "1",
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": schemas,
+		},
+	}
+
+	if len(undocumented) > 0 {
+		sort.Strings(undocumented)
+		return document, fmt.Errorf("These routes are not documented: %s", strings.Join(undocumented, ", "))
+	}
+
+	return document, nil
+}
+
+// normalizePath strips the patterns from path variables and replaces a
+// jurisdiction slug with {jurisdiction}.
+func normalizePath(template string, slugs []string) (string, bool) {
+	path := pathParamRe.ReplaceAllString(template, "{$1}")
+
+	for _, prefix := range []string{"/api/v2/", "/api/"} {
+		for _, slug := range slugs {
+			if strings.HasPrefix(path, prefix+slug+"/") {
+				return prefix + "{jurisdiction}/" + strings.TrimPrefix(path, prefix+slug+"/"), true
+			}
+		}
+	}
+
+	return path, false
+}
+
+func buildOperation(path string, doc operationDoc, isJurisdiction bool, slugs []string, schemas map[string]interface{}) map[string]interface{} {
+	var parameters []interface{}
+
+	if isJurisdiction {
+		parameters = append(parameters, map[string]interface{}{
+			"name":     "jurisdiction",
+			"in":       "path",
+			"required": true,
+			"schema":   map[string]interface{}{"type": "string", "enum": slugs},
+		})
+	}
+
+	for _, groups := range pathParamRe.FindAllStringSubmatch(path, -1) {
+		if groups[1] == "jurisdiction" {
+			continue
+		}
+
+		parameter := map[string]interface{}{
+			"name":     groups[1],
+			"in":       "path",
+			"required": true,
+			"schema":   map[string]interface{}{"type": "integer"},
+		}
+
+		for _, p := range doc.Path {
+			if p.Name == groups[1] {
+				parameter["description"] = p.Description
+				parameter["schema"] = map[string]interface{}{"type": p.Type}
+			}
+		}
+
+		parameters = append(parameters, parameter)
+	}
+
+	for _, query := range doc.Query {
+		parameters = append(parameters, map[string]interface{}{
+			"name":        query.Name,
+			"in":          "query",
+			"description": query.Description,
+			"schema":      map[string]interface{}{"type": query.Type},
+		})
+	}
+
+	media := map[string]interface{}{}
+	if doc.Response != nil {
+		media["schema"] = buildSchema(reflect.TypeOf(doc.Response), schemas)
+	}
+
+	content := map[string]interface{}{
+		doc.ContentType: media,
+	}
+
+	if doc.Negotiated {
+		for _, format := range dayFormats {
+			if _, ok := content[format.MediaType]; !ok {
+				content[format.MediaType] = map[string]interface{}{
+					"schema": map[string]interface{}{"type": "string"},
+				}
+			}
+		}
+	}
+
+	if doc.Streamed && doc.Response != nil {
+		content[NDJSONMediaType] = map[string]interface{}{
+			"schema": buildSchema(reflect.TypeOf(doc.Response).Elem(), schemas),
+		}
+	}
+
+	operation := map[string]interface{}{
+		"summary": doc.Summary,
+		"responses": map[string]interface{}{
+			"200": map[string]interface{}{
+				"description": "OK",
+				"content":     content,
+			},
+			"default": map[string]interface{}{
+				"description": "An error",
+				"content": map[string]interface{}{
+					"application/json": map[string]interface{}{
+						"schema": map[string]interface{}{"$ref": "#/components/schemas/ErrorResponse"},
+					},
+				},
+			},
+		},
+	}
+
+	if len(parameters) > 0 {
+		operation["parameters"] = parameters
+	}
+
+	if doc.Request != nil {
+		operation["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{
+					"schema": buildSchema(reflect.TypeOf(doc.Request), schemas),
+				},
+			},
+		}
+	}
+
+	return operation
+}
+
+// buildSchema returns a JSON schema for t. Named structs are added to
+// schemas and referred to by name.
+func buildSchema(t reflect.Type, schemas map[string]interface{}) map[string]interface{} {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return buildSchema(t.Elem(), schemas)
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": buildSchema(t.Elem(), schemas)}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": buildSchema(t.Elem(), schemas)}
+	case reflect.Struct:
+		name := t.Name()
+		ref := map[string]interface{}{"$ref": "#/components/schemas/" + name}
+		if _, ok := schemas[name]; ok {
+			return ref
+		}
+
+		// Reserve the name first so that recursive types terminate
+		schemas[name] = nil
+
+		properties := make(map[string]interface{})
+		var required []string
+		addStructFields(t, properties, &required, schemas)
+
+		schema := map[string]interface{}{
+			"type":       "object",
+			"properties": properties,
+		}
+		if len(required) > 0 {
+			sort.Strings(required)
+			schema["required"] = required
+		}
+
+		schemas[name] = schema
+		return ref
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// addStructFields follows the encoding/json rules for field names, including
+// promoting the fields of embedded structs.
+func addStructFields(t reflect.Type, properties map[string]interface{}, required *[]string, schemas map[string]interface{}) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && len(name) == 0 {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				addStructFields(embedded, properties, required, schemas)
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if len(name) == 0 {
+			name = field.Name
+		}
+
+		properties[name] = buildSchema(field.Type, schemas)
+		if !strings.Contains(options, "omitempty") {
+			*required = append(*required, name)
+		}
+	}
+}
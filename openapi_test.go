@@ -0,0 +1,52 @@
+package main
+
+import (
+	"database/sql"
+	"github.com/brianglass/orthocal"
+	"github.com/gorilla/mux"
+	"testing"
+)
+
+// newTestRouter mounts everything main does, using db as the calendar
+// database and bible as the default bible. Tests that never generate a day
+// may pass nil for both.
+func newTestRouter(t *testing.T, db *sql.DB, bible orthocal.Bible) (*mux.Router, []string) {
+	config, e := LoadConfig(DefaultConfigPath)
+	if e != nil {
+		t.Fatalf("Could not load %s: %v", DefaultConfigPath, e)
+	}
+
+	router := mux.NewRouter()
+	mountAPI(router, config, db, map[string]orthocal.Bible{config.DefaultBible: bible})
+
+	var slugs []string
+	for _, jurisdiction := range config.Jurisdictions {
+		slugs = append(slugs, jurisdiction.Slug)
+	}
+
+	return router, slugs
+}
+
+func TestOpenAPI(t *testing.T) {
+	router, slugs := newTestRouter(t, nil, nil)
+
+	document, e := BuildOpenAPI(router, slugs)
+	if e != nil {
+		t.Fatalf("%v", e)
+	}
+
+	// Every documented operation should still exist
+	paths := document["paths"].(map[string]interface{})
+	for path := range operationDocs {
+		if _, ok := paths[path]; !ok {
+			t.Errorf("%s is documented but has no route", path)
+		}
+	}
+
+	schemas := document["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	for _, name := range []string{"DayResponse", "Reading", "Verse", "V2Day", "V2Reading", "ErrorResponse"} {
+		if _, ok := schemas[name]; !ok {
+			t.Errorf("The %s schema is missing", name)
+		}
+	}
+}
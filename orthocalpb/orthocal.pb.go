@@ -0,0 +1,1069 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        v5.29.3
+// source: orthocalpb/orthocal.proto
+
+// The gRPC API mirrors the v2 REST API. Regenerate the Go code with
+// `make proto` after changing this file.
+
+package orthocalpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// A civil date. Months and days start at 1.
+type Date struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Year          int32                  `protobuf:"varint,1,opt,name=year,proto3" json:"year,omitempty"`
+	Month         int32                  `protobuf:"varint,2,opt,name=month,proto3" json:"month,omitempty"`
+	Day           int32                  `protobuf:"varint,3,opt,name=day,proto3" json:"day,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Date) Reset() {
+	*x = Date{}
+	mi := &file_orthocalpb_orthocal_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Date) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Date) ProtoMessage() {}
+
+func (x *Date) ProtoReflect() protoreflect.Message {
+	mi := &file_orthocalpb_orthocal_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Date.ProtoReflect.Descriptor instead.
+func (*Date) Descriptor() ([]byte, []int) {
+	return file_orthocalpb_orthocal_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Date) GetYear() int32 {
+	if x != nil {
+		return x.Year
+	}
+	return 0
+}
+
+func (x *Date) GetMonth() int32 {
+	if x != nil {
+		return x.Month
+	}
+	return 0
+}
+
+func (x *Date) GetDay() int32 {
+	if x != nil {
+		return x.Day
+	}
+	return 0
+}
+
+type GetDayRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Jurisdiction    string                 `protobuf:"bytes,1,opt,name=jurisdiction,proto3" json:"jurisdiction,omitempty"`
+	Date            *Date                  `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`
+	IncludePassages bool                   `protobuf:"varint,3,opt,name=include_passages,json=includePassages,proto3" json:"include_passages,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetDayRequest) Reset() {
+	*x = GetDayRequest{}
+	mi := &file_orthocalpb_orthocal_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDayRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDayRequest) ProtoMessage() {}
+
+func (x *GetDayRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orthocalpb_orthocal_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDayRequest.ProtoReflect.Descriptor instead.
+func (*GetDayRequest) Descriptor() ([]byte, []int) {
+	return file_orthocalpb_orthocal_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetDayRequest) GetJurisdiction() string {
+	if x != nil {
+		return x.Jurisdiction
+	}
+	return ""
+}
+
+func (x *GetDayRequest) GetDate() *Date {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *GetDayRequest) GetIncludePassages() bool {
+	if x != nil {
+		return x.IncludePassages
+	}
+	return false
+}
+
+type ListDaysRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Jurisdiction    string                 `protobuf:"bytes,1,opt,name=jurisdiction,proto3" json:"jurisdiction,omitempty"`
+	Start           *Date                  `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	End             *Date                  `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`
+	IncludePassages bool                   `protobuf:"varint,4,opt,name=include_passages,json=includePassages,proto3" json:"include_passages,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListDaysRequest) Reset() {
+	*x = ListDaysRequest{}
+	mi := &file_orthocalpb_orthocal_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDaysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDaysRequest) ProtoMessage() {}
+
+func (x *ListDaysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orthocalpb_orthocal_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDaysRequest.ProtoReflect.Descriptor instead.
+func (*ListDaysRequest) Descriptor() ([]byte, []int) {
+	return file_orthocalpb_orthocal_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListDaysRequest) GetJurisdiction() string {
+	if x != nil {
+		return x.Jurisdiction
+	}
+	return ""
+}
+
+func (x *ListDaysRequest) GetStart() *Date {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *ListDaysRequest) GetEnd() *Date {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *ListDaysRequest) GetIncludePassages() bool {
+	if x != nil {
+		return x.IncludePassages
+	}
+	return false
+}
+
+type GetPassageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reference     string                 `protobuf:"bytes,1,opt,name=reference,proto3" json:"reference,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPassageRequest) Reset() {
+	*x = GetPassageRequest{}
+	mi := &file_orthocalpb_orthocal_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPassageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPassageRequest) ProtoMessage() {}
+
+func (x *GetPassageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orthocalpb_orthocal_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPassageRequest.ProtoReflect.Descriptor instead.
+func (*GetPassageRequest) Descriptor() ([]byte, []int) {
+	return file_orthocalpb_orthocal_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetPassageRequest) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+type ListJurisdictionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJurisdictionsRequest) Reset() {
+	*x = ListJurisdictionsRequest{}
+	mi := &file_orthocalpb_orthocal_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJurisdictionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJurisdictionsRequest) ProtoMessage() {}
+
+func (x *ListJurisdictionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orthocalpb_orthocal_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJurisdictionsRequest.ProtoReflect.Descriptor instead.
+func (*ListJurisdictionsRequest) Descriptor() ([]byte, []int) {
+	return file_orthocalpb_orthocal_proto_rawDescGZIP(), []int{4}
+}
+
+type ListJurisdictionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Jurisdictions []*Jurisdiction        `protobuf:"bytes,1,rep,name=jurisdictions,proto3" json:"jurisdictions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJurisdictionsResponse) Reset() {
+	*x = ListJurisdictionsResponse{}
+	mi := &file_orthocalpb_orthocal_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJurisdictionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJurisdictionsResponse) ProtoMessage() {}
+
+func (x *ListJurisdictionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_orthocalpb_orthocal_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJurisdictionsResponse.ProtoReflect.Descriptor instead.
+func (*ListJurisdictionsResponse) Descriptor() ([]byte, []int) {
+	return file_orthocalpb_orthocal_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListJurisdictionsResponse) GetJurisdictions() []*Jurisdiction {
+	if x != nil {
+		return x.Jurisdictions
+	}
+	return nil
+}
+
+type Jurisdiction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Slug          string                 `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Julian        bool                   `protobuf:"varint,3,opt,name=julian,proto3" json:"julian,omitempty"`
+	LukanJump     bool                   `protobuf:"varint,4,opt,name=lukan_jump,json=lukanJump,proto3" json:"lukan_jump,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Jurisdiction) Reset() {
+	*x = Jurisdiction{}
+	mi := &file_orthocalpb_orthocal_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Jurisdiction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Jurisdiction) ProtoMessage() {}
+
+func (x *Jurisdiction) ProtoReflect() protoreflect.Message {
+	mi := &file_orthocalpb_orthocal_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Jurisdiction.ProtoReflect.Descriptor instead.
+func (*Jurisdiction) Descriptor() ([]byte, []int) {
+	return file_orthocalpb_orthocal_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Jurisdiction) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *Jurisdiction) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Jurisdiction) GetJulian() bool {
+	if x != nil {
+		return x.Julian
+	}
+	return false
+}
+
+func (x *Jurisdiction) GetLukanJump() bool {
+	if x != nil {
+		return x.LukanJump
+	}
+	return false
+}
+
+type Day struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Date           *Date                  `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	Weekday        string                 `protobuf:"bytes,2,opt,name=weekday,proto3" json:"weekday,omitempty"`
+	Jurisdiction   string                 `protobuf:"bytes,3,opt,name=jurisdiction,proto3" json:"jurisdiction,omitempty"`
+	Titles         []string               `protobuf:"bytes,4,rep,name=titles,proto3" json:"titles,omitempty"`
+	Commemorations *Commemorations        `protobuf:"bytes,5,opt,name=commemorations,proto3" json:"commemorations,omitempty"`
+	Fasting        *Fasting               `protobuf:"bytes,6,opt,name=fasting,proto3" json:"fasting,omitempty"`
+	Readings       []*Reading             `protobuf:"bytes,7,rep,name=readings,proto3" json:"readings,omitempty"`
+	// The Julian calendar date; only set for jurisdictions on the Julian
+	// calendar.
+	OldStyleDate  *Date `protobuf:"bytes,8,opt,name=old_style_date,json=oldStyleDate,proto3" json:"old_style_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Day) Reset() {
+	*x = Day{}
+	mi := &file_orthocalpb_orthocal_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Day) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Day) ProtoMessage() {}
+
+func (x *Day) ProtoReflect() protoreflect.Message {
+	mi := &file_orthocalpb_orthocal_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Day.ProtoReflect.Descriptor instead.
+func (*Day) Descriptor() ([]byte, []int) {
+	return file_orthocalpb_orthocal_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Day) GetDate() *Date {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *Day) GetWeekday() string {
+	if x != nil {
+		return x.Weekday
+	}
+	return ""
+}
+
+func (x *Day) GetJurisdiction() string {
+	if x != nil {
+		return x.Jurisdiction
+	}
+	return ""
+}
+
+func (x *Day) GetTitles() []string {
+	if x != nil {
+		return x.Titles
+	}
+	return nil
+}
+
+func (x *Day) GetCommemorations() *Commemorations {
+	if x != nil {
+		return x.Commemorations
+	}
+	return nil
+}
+
+func (x *Day) GetFasting() *Fasting {
+	if x != nil {
+		return x.Fasting
+	}
+	return nil
+}
+
+func (x *Day) GetReadings() []*Reading {
+	if x != nil {
+		return x.Readings
+	}
+	return nil
+}
+
+func (x *Day) GetOldStyleDate() *Date {
+	if x != nil {
+		return x.OldStyleDate
+	}
+	return nil
+}
+
+type Commemorations struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Feasts        []string               `protobuf:"bytes,1,rep,name=feasts,proto3" json:"feasts,omitempty"`
+	Saints        []string               `protobuf:"bytes,2,rep,name=saints,proto3" json:"saints,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Commemorations) Reset() {
+	*x = Commemorations{}
+	mi := &file_orthocalpb_orthocal_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Commemorations) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Commemorations) ProtoMessage() {}
+
+func (x *Commemorations) ProtoReflect() protoreflect.Message {
+	mi := &file_orthocalpb_orthocal_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Commemorations.ProtoReflect.Descriptor instead.
+func (*Commemorations) Descriptor() ([]byte, []int) {
+	return file_orthocalpb_orthocal_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Commemorations) GetFeasts() []string {
+	if x != nil {
+		return x.Feasts
+	}
+	return nil
+}
+
+func (x *Commemorations) GetSaints() []string {
+	if x != nil {
+		return x.Saints
+	}
+	return nil
+}
+
+type Fasting struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Level         int32                  `protobuf:"varint,1,opt,name=level,proto3" json:"level,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Exception     string                 `protobuf:"bytes,3,opt,name=exception,proto3" json:"exception,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Fasting) Reset() {
+	*x = Fasting{}
+	mi := &file_orthocalpb_orthocal_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Fasting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Fasting) ProtoMessage() {}
+
+func (x *Fasting) ProtoReflect() protoreflect.Message {
+	mi := &file_orthocalpb_orthocal_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Fasting.ProtoReflect.Descriptor instead.
+func (*Fasting) Descriptor() ([]byte, []int) {
+	return file_orthocalpb_orthocal_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Fasting) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *Fasting) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Fasting) GetException() string {
+	if x != nil {
+		return x.Exception
+	}
+	return ""
+}
+
+type Reading struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Source      string                 `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Book        string                 `protobuf:"bytes,2,opt,name=book,proto3" json:"book,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Display     string                 `protobuf:"bytes,4,opt,name=display,proto3" json:"display,omitempty"`
+	References  []*ScriptureRange      `protobuf:"bytes,5,rep,name=references,proto3" json:"references,omitempty"`
+	// Only populated when passages are requested.
+	Passage       []*Verse `protobuf:"bytes,6,rep,name=passage,proto3" json:"passage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Reading) Reset() {
+	*x = Reading{}
+	mi := &file_orthocalpb_orthocal_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reading) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reading) ProtoMessage() {}
+
+func (x *Reading) ProtoReflect() protoreflect.Message {
+	mi := &file_orthocalpb_orthocal_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reading.ProtoReflect.Descriptor instead.
+func (*Reading) Descriptor() ([]byte, []int) {
+	return file_orthocalpb_orthocal_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Reading) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *Reading) GetBook() string {
+	if x != nil {
+		return x.Book
+	}
+	return ""
+}
+
+func (x *Reading) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Reading) GetDisplay() string {
+	if x != nil {
+		return x.Display
+	}
+	return ""
+}
+
+func (x *Reading) GetReferences() []*ScriptureRange {
+	if x != nil {
+		return x.References
+	}
+	return nil
+}
+
+func (x *Reading) GetPassage() []*Verse {
+	if x != nil {
+		return x.Passage
+	}
+	return nil
+}
+
+// A verse of 0 means the whole chapter.
+type ScriptureRange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Book          string                 `protobuf:"bytes,1,opt,name=book,proto3" json:"book,omitempty"`
+	StartChapter  int32                  `protobuf:"varint,2,opt,name=start_chapter,json=startChapter,proto3" json:"start_chapter,omitempty"`
+	StartVerse    int32                  `protobuf:"varint,3,opt,name=start_verse,json=startVerse,proto3" json:"start_verse,omitempty"`
+	EndChapter    int32                  `protobuf:"varint,4,opt,name=end_chapter,json=endChapter,proto3" json:"end_chapter,omitempty"`
+	EndVerse      int32                  `protobuf:"varint,5,opt,name=end_verse,json=endVerse,proto3" json:"end_verse,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScriptureRange) Reset() {
+	*x = ScriptureRange{}
+	mi := &file_orthocalpb_orthocal_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScriptureRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScriptureRange) ProtoMessage() {}
+
+func (x *ScriptureRange) ProtoReflect() protoreflect.Message {
+	mi := &file_orthocalpb_orthocal_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScriptureRange.ProtoReflect.Descriptor instead.
+func (*ScriptureRange) Descriptor() ([]byte, []int) {
+	return file_orthocalpb_orthocal_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ScriptureRange) GetBook() string {
+	if x != nil {
+		return x.Book
+	}
+	return ""
+}
+
+func (x *ScriptureRange) GetStartChapter() int32 {
+	if x != nil {
+		return x.StartChapter
+	}
+	return 0
+}
+
+func (x *ScriptureRange) GetStartVerse() int32 {
+	if x != nil {
+		return x.StartVerse
+	}
+	return 0
+}
+
+func (x *ScriptureRange) GetEndChapter() int32 {
+	if x != nil {
+		return x.EndChapter
+	}
+	return 0
+}
+
+func (x *ScriptureRange) GetEndVerse() int32 {
+	if x != nil {
+		return x.EndVerse
+	}
+	return 0
+}
+
+type Passage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reference     string                 `protobuf:"bytes,1,opt,name=reference,proto3" json:"reference,omitempty"`
+	Verses        []*Verse               `protobuf:"bytes,2,rep,name=verses,proto3" json:"verses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Passage) Reset() {
+	*x = Passage{}
+	mi := &file_orthocalpb_orthocal_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Passage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Passage) ProtoMessage() {}
+
+func (x *Passage) ProtoReflect() protoreflect.Message {
+	mi := &file_orthocalpb_orthocal_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Passage.ProtoReflect.Descriptor instead.
+func (*Passage) Descriptor() ([]byte, []int) {
+	return file_orthocalpb_orthocal_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Passage) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *Passage) GetVerses() []*Verse {
+	if x != nil {
+		return x.Verses
+	}
+	return nil
+}
+
+type Verse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Book           string                 `protobuf:"bytes,1,opt,name=book,proto3" json:"book,omitempty"`
+	Chapter        int32                  `protobuf:"varint,2,opt,name=chapter,proto3" json:"chapter,omitempty"`
+	Verse          int32                  `protobuf:"varint,3,opt,name=verse,proto3" json:"verse,omitempty"`
+	Text           string                 `protobuf:"bytes,4,opt,name=text,proto3" json:"text,omitempty"`
+	ParagraphStart bool                   `protobuf:"varint,5,opt,name=paragraph_start,json=paragraphStart,proto3" json:"paragraph_start,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Verse) Reset() {
+	*x = Verse{}
+	mi := &file_orthocalpb_orthocal_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Verse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Verse) ProtoMessage() {}
+
+func (x *Verse) ProtoReflect() protoreflect.Message {
+	mi := &file_orthocalpb_orthocal_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Verse.ProtoReflect.Descriptor instead.
+func (*Verse) Descriptor() ([]byte, []int) {
+	return file_orthocalpb_orthocal_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Verse) GetBook() string {
+	if x != nil {
+		return x.Book
+	}
+	return ""
+}
+
+func (x *Verse) GetChapter() int32 {
+	if x != nil {
+		return x.Chapter
+	}
+	return 0
+}
+
+func (x *Verse) GetVerse() int32 {
+	if x != nil {
+		return x.Verse
+	}
+	return 0
+}
+
+func (x *Verse) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Verse) GetParagraphStart() bool {
+	if x != nil {
+		return x.ParagraphStart
+	}
+	return false
+}
+
+var File_orthocalpb_orthocal_proto protoreflect.FileDescriptor
+
+const file_orthocalpb_orthocal_proto_rawDesc = "" +
+	"\n" +
+	"\x19orthocalpb/orthocal.proto\x12\vorthocal.v1\"B\n" +
+	"\x04Date\x12\x12\n" +
+	"\x04year\x18\x01 \x01(\x05R\x04year\x12\x14\n" +
+	"\x05month\x18\x02 \x01(\x05R\x05month\x12\x10\n" +
+	"\x03day\x18\x03 \x01(\x05R\x03day\"\x85\x01\n" +
+	"\rGetDayRequest\x12\"\n" +
+	"\fjurisdiction\x18\x01 \x01(\tR\fjurisdiction\x12%\n" +
+	"\x04date\x18\x02 \x01(\v2\x11.orthocal.v1.DateR\x04date\x12)\n" +
+	"\x10include_passages\x18\x03 \x01(\bR\x0fincludePassages\"\xae\x01\n" +
+	"\x0fListDaysRequest\x12\"\n" +
+	"\fjurisdiction\x18\x01 \x01(\tR\fjurisdiction\x12'\n" +
+	"\x05start\x18\x02 \x01(\v2\x11.orthocal.v1.DateR\x05start\x12#\n" +
+	"\x03end\x18\x03 \x01(\v2\x11.orthocal.v1.DateR\x03end\x12)\n" +
+	"\x10include_passages\x18\x04 \x01(\bR\x0fincludePassages\"1\n" +
+	"\x11GetPassageRequest\x12\x1c\n" +
+	"\treference\x18\x01 \x01(\tR\treference\"\x1a\n" +
+	"\x18ListJurisdictionsRequest\"\\\n" +
+	"\x19ListJurisdictionsResponse\x12?\n" +
+	"\rjurisdictions\x18\x01 \x03(\v2\x19.orthocal.v1.JurisdictionR\rjurisdictions\"o\n" +
+	"\fJurisdiction\x12\x12\n" +
+	"\x04slug\x18\x01 \x01(\tR\x04slug\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
+	"\x06julian\x18\x03 \x01(\bR\x06julian\x12\x1d\n" +
+	"\n" +
+	"lukan_jump\x18\x04 \x01(\bR\tlukanJump\"\xe2\x02\n" +
+	"\x03Day\x12%\n" +
+	"\x04date\x18\x01 \x01(\v2\x11.orthocal.v1.DateR\x04date\x12\x18\n" +
+	"\aweekday\x18\x02 \x01(\tR\aweekday\x12\"\n" +
+	"\fjurisdiction\x18\x03 \x01(\tR\fjurisdiction\x12\x16\n" +
+	"\x06titles\x18\x04 \x03(\tR\x06titles\x12C\n" +
+	"\x0ecommemorations\x18\x05 \x01(\v2\x1b.orthocal.v1.CommemorationsR\x0ecommemorations\x12.\n" +
+	"\afasting\x18\x06 \x01(\v2\x14.orthocal.v1.FastingR\afasting\x120\n" +
+	"\breadings\x18\a \x03(\v2\x14.orthocal.v1.ReadingR\breadings\x127\n" +
+	"\x0eold_style_date\x18\b \x01(\v2\x11.orthocal.v1.DateR\foldStyleDate\"@\n" +
+	"\x0eCommemorations\x12\x16\n" +
+	"\x06feasts\x18\x01 \x03(\tR\x06feasts\x12\x16\n" +
+	"\x06saints\x18\x02 \x03(\tR\x06saints\"_\n" +
+	"\aFasting\x12\x14\n" +
+	"\x05level\x18\x01 \x01(\x05R\x05level\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1c\n" +
+	"\texception\x18\x03 \x01(\tR\texception\"\xdc\x01\n" +
+	"\aReading\x12\x16\n" +
+	"\x06source\x18\x01 \x01(\tR\x06source\x12\x12\n" +
+	"\x04book\x18\x02 \x01(\tR\x04book\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x18\n" +
+	"\adisplay\x18\x04 \x01(\tR\adisplay\x12;\n" +
+	"\n" +
+	"references\x18\x05 \x03(\v2\x1b.orthocal.v1.ScriptureRangeR\n" +
+	"references\x12,\n" +
+	"\apassage\x18\x06 \x03(\v2\x12.orthocal.v1.VerseR\apassage\"\xa8\x01\n" +
+	"\x0eScriptureRange\x12\x12\n" +
+	"\x04book\x18\x01 \x01(\tR\x04book\x12#\n" +
+	"\rstart_chapter\x18\x02 \x01(\x05R\fstartChapter\x12\x1f\n" +
+	"\vstart_verse\x18\x03 \x01(\x05R\n" +
+	"startVerse\x12\x1f\n" +
+	"\vend_chapter\x18\x04 \x01(\x05R\n" +
+	"endChapter\x12\x1b\n" +
+	"\tend_verse\x18\x05 \x01(\x05R\bendVerse\"S\n" +
+	"\aPassage\x12\x1c\n" +
+	"\treference\x18\x01 \x01(\tR\treference\x12*\n" +
+	"\x06verses\x18\x02 \x03(\v2\x12.orthocal.v1.VerseR\x06verses\"\x88\x01\n" +
+	"\x05Verse\x12\x12\n" +
+	"\x04book\x18\x01 \x01(\tR\x04book\x12\x18\n" +
+	"\achapter\x18\x02 \x01(\x05R\achapter\x12\x14\n" +
+	"\x05verse\x18\x03 \x01(\x05R\x05verse\x12\x12\n" +
+	"\x04text\x18\x04 \x01(\tR\x04text\x12'\n" +
+	"\x0fparagraph_start\x18\x05 \x01(\bR\x0eparagraphStart2\xa8\x02\n" +
+	"\bCalendar\x126\n" +
+	"\x06GetDay\x12\x1a.orthocal.v1.GetDayRequest\x1a\x10.orthocal.v1.Day\x12<\n" +
+	"\bListDays\x12\x1c.orthocal.v1.ListDaysRequest\x1a\x10.orthocal.v1.Day0\x01\x12B\n" +
+	"\n" +
+	"GetPassage\x12\x1e.orthocal.v1.GetPassageRequest\x1a\x14.orthocal.v1.Passage\x12b\n" +
+	"\x11ListJurisdictions\x12%.orthocal.v1.ListJurisdictionsRequest\x1a&.orthocal.v1.ListJurisdictionsResponseB3Z1github.com/brianglass/orthocal-service/orthocalpbb\x06proto3"
+
+var (
+	file_orthocalpb_orthocal_proto_rawDescOnce sync.Once
+	file_orthocalpb_orthocal_proto_rawDescData []byte
+)
+
+func file_orthocalpb_orthocal_proto_rawDescGZIP() []byte {
+	file_orthocalpb_orthocal_proto_rawDescOnce.Do(func() {
+		file_orthocalpb_orthocal_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_orthocalpb_orthocal_proto_rawDesc), len(file_orthocalpb_orthocal_proto_rawDesc)))
+	})
+	return file_orthocalpb_orthocal_proto_rawDescData
+}
+
+var file_orthocalpb_orthocal_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_orthocalpb_orthocal_proto_goTypes = []any{
+	(*Date)(nil),                      // 0: orthocal.v1.Date
+	(*GetDayRequest)(nil),             // 1: orthocal.v1.GetDayRequest
+	(*ListDaysRequest)(nil),           // 2: orthocal.v1.ListDaysRequest
+	(*GetPassageRequest)(nil),         // 3: orthocal.v1.GetPassageRequest
+	(*ListJurisdictionsRequest)(nil),  // 4: orthocal.v1.ListJurisdictionsRequest
+	(*ListJurisdictionsResponse)(nil), // 5: orthocal.v1.ListJurisdictionsResponse
+	(*Jurisdiction)(nil),              // 6: orthocal.v1.Jurisdiction
+	(*Day)(nil),                       // 7: orthocal.v1.Day
+	(*Commemorations)(nil),            // 8: orthocal.v1.Commemorations
+	(*Fasting)(nil),                   // 9: orthocal.v1.Fasting
+	(*Reading)(nil),                   // 10: orthocal.v1.Reading
+	(*ScriptureRange)(nil),            // 11: orthocal.v1.ScriptureRange
+	(*Passage)(nil),                   // 12: orthocal.v1.Passage
+	(*Verse)(nil),                     // 13: orthocal.v1.Verse
+}
+var file_orthocalpb_orthocal_proto_depIdxs = []int32{
+	0,  // 0: orthocal.v1.GetDayRequest.date:type_name -> orthocal.v1.Date
+	0,  // 1: orthocal.v1.ListDaysRequest.start:type_name -> orthocal.v1.Date
+	0,  // 2: orthocal.v1.ListDaysRequest.end:type_name -> orthocal.v1.Date
+	6,  // 3: orthocal.v1.ListJurisdictionsResponse.jurisdictions:type_name -> orthocal.v1.Jurisdiction
+	0,  // 4: orthocal.v1.Day.date:type_name -> orthocal.v1.Date
+	8,  // 5: orthocal.v1.Day.commemorations:type_name -> orthocal.v1.Commemorations
+	9,  // 6: orthocal.v1.Day.fasting:type_name -> orthocal.v1.Fasting
+	10, // 7: orthocal.v1.Day.readings:type_name -> orthocal.v1.Reading
+	0,  // 8: orthocal.v1.Day.old_style_date:type_name -> orthocal.v1.Date
+	11, // 9: orthocal.v1.Reading.references:type_name -> orthocal.v1.ScriptureRange
+	13, // 10: orthocal.v1.Reading.passage:type_name -> orthocal.v1.Verse
+	13, // 11: orthocal.v1.Passage.verses:type_name -> orthocal.v1.Verse
+	1,  // 12: orthocal.v1.Calendar.GetDay:input_type -> orthocal.v1.GetDayRequest
+	2,  // 13: orthocal.v1.Calendar.ListDays:input_type -> orthocal.v1.ListDaysRequest
+	3,  // 14: orthocal.v1.Calendar.GetPassage:input_type -> orthocal.v1.GetPassageRequest
+	4,  // 15: orthocal.v1.Calendar.ListJurisdictions:input_type -> orthocal.v1.ListJurisdictionsRequest
+	7,  // 16: orthocal.v1.Calendar.GetDay:output_type -> orthocal.v1.Day
+	7,  // 17: orthocal.v1.Calendar.ListDays:output_type -> orthocal.v1.Day
+	12, // 18: orthocal.v1.Calendar.GetPassage:output_type -> orthocal.v1.Passage
+	5,  // 19: orthocal.v1.Calendar.ListJurisdictions:output_type -> orthocal.v1.ListJurisdictionsResponse
+	16, // [16:20] is the sub-list for method output_type
+	12, // [12:16] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_orthocalpb_orthocal_proto_init() }
+func file_orthocalpb_orthocal_proto_init() {
+	if File_orthocalpb_orthocal_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_orthocalpb_orthocal_proto_rawDesc), len(file_orthocalpb_orthocal_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   14,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_orthocalpb_orthocal_proto_goTypes,
+		DependencyIndexes: file_orthocalpb_orthocal_proto_depIdxs,
+		MessageInfos:      file_orthocalpb_orthocal_proto_msgTypes,
+	}.Build()
+	File_orthocalpb_orthocal_proto = out.File
+	file_orthocalpb_orthocal_proto_goTypes = nil
+	file_orthocalpb_orthocal_proto_depIdxs = nil
+}
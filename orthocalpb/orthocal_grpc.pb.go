@@ -0,0 +1,250 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: orthocalpb/orthocal.proto
+
+// The gRPC API mirrors the v2 REST API. Regenerate the Go code with
+// `make proto` after changing this file.
+
+package orthocalpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Calendar_GetDay_FullMethodName            = "/orthocal.v1.Calendar/GetDay"
+	Calendar_ListDays_FullMethodName          = "/orthocal.v1.Calendar/ListDays"
+	Calendar_GetPassage_FullMethodName        = "/orthocal.v1.Calendar/GetPassage"
+	Calendar_ListJurisdictions_FullMethodName = "/orthocal.v1.Calendar/ListJurisdictions"
+)
+
+// CalendarClient is the client API for Calendar service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CalendarClient interface {
+	// GetDay returns a single day for a jurisdiction.
+	GetDay(ctx context.Context, in *GetDayRequest, opts ...grpc.CallOption) (*Day, error)
+	// ListDays streams each day from start through end, inclusive.
+	ListDays(ctx context.Context, in *ListDaysRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Day], error)
+	// GetPassage looks up a scripture reference such as "John 1:1-17".
+	GetPassage(ctx context.Context, in *GetPassageRequest, opts ...grpc.CallOption) (*Passage, error)
+	// ListJurisdictions describes every jurisdiction the service knows about.
+	ListJurisdictions(ctx context.Context, in *ListJurisdictionsRequest, opts ...grpc.CallOption) (*ListJurisdictionsResponse, error)
+}
+
+type calendarClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCalendarClient(cc grpc.ClientConnInterface) CalendarClient {
+	return &calendarClient{cc}
+}
+
+func (c *calendarClient) GetDay(ctx context.Context, in *GetDayRequest, opts ...grpc.CallOption) (*Day, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Day)
+	err := c.cc.Invoke(ctx, Calendar_GetDay_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarClient) ListDays(ctx context.Context, in *ListDaysRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Day], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Calendar_ServiceDesc.Streams[0], Calendar_ListDays_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListDaysRequest, Day]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Calendar_ListDaysClient = grpc.ServerStreamingClient[Day]
+
+func (c *calendarClient) GetPassage(ctx context.Context, in *GetPassageRequest, opts ...grpc.CallOption) (*Passage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Passage)
+	err := c.cc.Invoke(ctx, Calendar_GetPassage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarClient) ListJurisdictions(ctx context.Context, in *ListJurisdictionsRequest, opts ...grpc.CallOption) (*ListJurisdictionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListJurisdictionsResponse)
+	err := c.cc.Invoke(ctx, Calendar_ListJurisdictions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CalendarServer is the server API for Calendar service.
+// All implementations must embed UnimplementedCalendarServer
+// for forward compatibility.
+type CalendarServer interface {
+	// GetDay returns a single day for a jurisdiction.
+	GetDay(context.Context, *GetDayRequest) (*Day, error)
+	// ListDays streams each day from start through end, inclusive.
+	ListDays(*ListDaysRequest, grpc.ServerStreamingServer[Day]) error
+	// GetPassage looks up a scripture reference such as "John 1:1-17".
+	GetPassage(context.Context, *GetPassageRequest) (*Passage, error)
+	// ListJurisdictions describes every jurisdiction the service knows about.
+	ListJurisdictions(context.Context, *ListJurisdictionsRequest) (*ListJurisdictionsResponse, error)
+	mustEmbedUnimplementedCalendarServer()
+}
+
+// UnimplementedCalendarServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCalendarServer struct{}
+
+func (UnimplementedCalendarServer) GetDay(context.Context, *GetDayRequest) (*Day, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDay not implemented")
+}
+func (UnimplementedCalendarServer) ListDays(*ListDaysRequest, grpc.ServerStreamingServer[Day]) error {
+	return status.Errorf(codes.Unimplemented, "method ListDays not implemented")
+}
+func (UnimplementedCalendarServer) GetPassage(context.Context, *GetPassageRequest) (*Passage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPassage not implemented")
+}
+func (UnimplementedCalendarServer) ListJurisdictions(context.Context, *ListJurisdictionsRequest) (*ListJurisdictionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListJurisdictions not implemented")
+}
+func (UnimplementedCalendarServer) mustEmbedUnimplementedCalendarServer() {}
+func (UnimplementedCalendarServer) testEmbeddedByValue()                  {}
+
+// UnsafeCalendarServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CalendarServer will
+// result in compilation errors.
+type UnsafeCalendarServer interface {
+	mustEmbedUnimplementedCalendarServer()
+}
+
+func RegisterCalendarServer(s grpc.ServiceRegistrar, srv CalendarServer) {
+	// If the following call pancis, it indicates UnimplementedCalendarServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Calendar_ServiceDesc, srv)
+}
+
+func _Calendar_GetDay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServer).GetDay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Calendar_GetDay_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServer).GetDay(ctx, req.(*GetDayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Calendar_ListDays_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListDaysRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CalendarServer).ListDays(m, &grpc.GenericServerStream[ListDaysRequest, Day]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Calendar_ListDaysServer = grpc.ServerStreamingServer[Day]
+
+func _Calendar_GetPassage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPassageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServer).GetPassage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Calendar_GetPassage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServer).GetPassage(ctx, req.(*GetPassageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Calendar_ListJurisdictions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJurisdictionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServer).ListJurisdictions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Calendar_ListJurisdictions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServer).ListJurisdictions(ctx, req.(*ListJurisdictionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Calendar_ServiceDesc is the grpc.ServiceDesc for Calendar service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Calendar_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "orthocal.v1.Calendar",
+	HandlerType: (*CalendarServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetDay",
+			Handler:    _Calendar_GetDay_Handler,
+		},
+		{
+			MethodName: "GetPassage",
+			Handler:    _Calendar_GetPassage_Handler,
+		},
+		{
+			MethodName: "ListJurisdictions",
+			Handler:    _Calendar_ListJurisdictions_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListDays",
+			Handler:       _Calendar_ListDays_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "orthocalpb/orthocal.proto",
+}
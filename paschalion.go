@@ -0,0 +1,86 @@
+package main
+
+import (
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Paschalion describes the moveable cycle of a year. Every date is the civil
+// (Gregorian) date on which the event falls.
+type Paschalion struct {
+	Year               int    `json:"year"`
+	Pascha             string `json:"pascha"`
+	MeatfareSunday     string `json:"meatfare_sunday"`
+	CheesefareSunday   string `json:"cheesefare_sunday"`
+	GreatLentBegins    string `json:"great_lent_begins"`
+	PalmSunday         string `json:"palm_sunday"`
+	Ascension          string `json:"ascension"`
+	Pentecost          string `json:"pentecost"`
+	AllSaints          string `json:"all_saints"`
+	ApostlesFastBegins string `json:"apostles_fast_begins,omitempty"`
+	ApostlesFastEnds   string `json:"apostles_fast_ends,omitempty"`
+	ApostlesFastDays   int    `json:"apostles_fast_days"`
+}
+
+// NewPaschalion computes the moveable cycle for a year. The only thing that
+// depends on the calendar style is the Apostles' Fast, which ends on the eve
+// of the fixed feast of Sts. Peter and Paul.
+func NewPaschalion(year int, useJulian bool) Paschalion {
+	const format = "2006-01-02"
+
+	pascha := ComputePascha(year)
+	offset := func(days int) string {
+		return pascha.AddDate(0, 0, days).Format(format)
+	}
+
+	p := Paschalion{
+		Year:             year,
+		Pascha:           pascha.Format(format),
+		MeatfareSunday:   offset(-56),
+		CheesefareSunday: offset(-49),
+		GreatLentBegins:  offset(-48),
+		PalmSunday:       offset(-7),
+		Ascension:        offset(39),
+		Pentecost:        offset(49),
+		AllSaints:        offset(56),
+	}
+
+	// The fast begins the day after All Saints and lasts until June 28. In
+	// years when Pascha is late on the Revised Julian calendar, there may be
+	// no fast at all.
+	begins := pascha.AddDate(0, 0, 57)
+	ends := FixedFeastDate(useJulian, year, 6, 28)
+	if !ends.Before(begins) {
+		p.ApostlesFastBegins = begins.Format(format)
+		p.ApostlesFastEnds = ends.Format(format)
+		p.ApostlesFastDays = int(ends.Sub(begins)/(24*time.Hour)) + 1
+	}
+
+	return p
+}
+
+func (self *CalendarServer) paschalionHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+
+	// Mux is setup to only send things that match this pattern, so we don't
+	// need to handle the errors.
+	year, _ := strconv.Atoi(vars["year"])
+
+	if !validYear(writer, request, year) {
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.Header().Set("Cache-Control", YearCacheControl)
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "\t")
+
+	if e := encoder.Encode(NewPaschalion(year, self.jurisdiction.UseJulian)); e != nil {
+		writeError(writer, request, http.StatusInternalServerError, "Internal Server Error")
+		log.Printf("Could not marshal json for paschalionHandler: %#v.", e)
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestComputePascha(t *testing.T) {
+	testCases := []struct {
+		year   int
+		pascha string
+	}{
+		{1900, "1900-04-22"},
+		{2000, "2000-04-30"},
+		{2019, "2019-04-28"},
+		{2024, "2024-05-05"},
+		{2025, "2025-04-20"},
+		{2026, "2026-04-12"},
+		{2027, "2027-05-02"},
+		{2099, "2099-04-12"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.pascha, func(t *testing.T) {
+			if pascha := ComputePascha(tc.year).Format("2006-01-02"); pascha != tc.pascha {
+				t.Errorf("Pascha should be %s but is %s", tc.pascha, pascha)
+			}
+		})
+	}
+}
+
+func TestJulianDate(t *testing.T) {
+	testCases := []struct {
+		year, month, day int
+		civil            string
+	}{
+		{2023, 12, 25, "2024-01-07"},
+		{1900, 2, 28, "1900-03-12"},
+		// 1900 was a leap year on the Julian calendar but not the Gregorian
+		{1900, 2, 29, "1900-03-13"},
+		{1900, 3, 1, "1900-03-14"},
+		{2100, 2, 28, "2100-03-13"},
+		{2100, 2, 29, "2100-03-14"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.civil, func(t *testing.T) {
+			if civil := JulianDate(tc.year, tc.month, tc.day).Format("2006-01-02"); civil != tc.civil {
+				t.Errorf("civil date should be %s but is %s", tc.civil, civil)
+			}
+		})
+	}
+}
+
+func TestApostlesFast(t *testing.T) {
+	testCases := []struct {
+		year      int
+		useJulian bool
+		days      int
+	}{
+		// In 2024 Pascha was late enough that there was no fast on the
+		// Revised Julian calendar
+		{2024, false, 0},
+		{2024, true, 11},
+		{2025, false, 13},
+		{2025, true, 26},
+	}
+
+	for _, tc := range testCases {
+		t.Run("Apostles Fast", func(t *testing.T) {
+			p := NewPaschalion(tc.year, tc.useJulian)
+			if p.ApostlesFastDays != tc.days {
+				t.Errorf("Apostles' Fast in %d should be %d days but is %d", tc.year, tc.days, p.ApostlesFastDays)
+			}
+		})
+	}
+}
+
+func TestConvertDate(t *testing.T) {
+	testCases := []struct {
+		date     string
+		from, to string
+		result   string
+	}{
+		{"2025-01-07", GregorianCalendar, JulianCalendar, "2024-12-25"},
+		{"2024-12-25", JulianCalendar, GregorianCalendar, "2025-01-07"},
+		{"1900-03-13", GregorianCalendar, JulianCalendar, "1900-02-29"},
+		{"1900-02-29", JulianCalendar, GregorianCalendar, "1900-03-13"},
+		{"2100-03-14", GregorianCalendar, JulianCalendar, "2100-02-29"},
+		{"2100-03-15", GregorianCalendar, JulianCalendar, "2100-03-01"},
+		{"2025-04-20", GregorianCalendar, GregorianCalendar, "2025-04-20"},
+		// These don't exist
+		{"1900-02-29", GregorianCalendar, JulianCalendar, ""},
+		{"2025-13-01", JulianCalendar, GregorianCalendar, ""},
+		{"2025-01-01", "hebrew", GregorianCalendar, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.date+" "+tc.from, func(t *testing.T) {
+			var year, month, day int
+			fmt.Sscanf(tc.date, "%d-%d-%d", &year, &month, &day)
+
+			y, m, d, ok := ConvertDate(year, month, day, tc.from, tc.to)
+			if ok != (len(tc.result) > 0) {
+				t.Fatalf("ok should be %t but is %t", len(tc.result) > 0, ok)
+			}
+
+			if result := fmt.Sprintf("%04d-%02d-%02d", y, m, d); ok && result != tc.result {
+				t.Errorf("result should be %s but is %s", tc.result, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Splits a reference such as "1 Cor 1.10-18" into its book and the rest
+var bookRe = regexp.MustCompile(`^(\d?\s*[A-Za-z][A-Za-z.\s]*?)\s*(\d.*)$`)
+
+// ScriptureRange is a contiguous span of verses. A verse of zero means the
+// range starts or ends on a chapter boundary, so "Wis 4" is chapter 4, verse
+// 0 through chapter 4, verse 0.
+type ScriptureRange struct {
+	Book         string `json:"book"`
+	StartChapter int    `json:"start_chapter"`
+	StartVerse   int    `json:"start_verse"`
+	EndChapter   int    `json:"end_chapter"`
+	EndVerse     int    `json:"end_verse"`
+}
+
+// ParseReference splits a scripture reference as displayed by orthocal, such
+// as "Matt 10.32-33, 37-38, 19.27-30", into its ranges. Chapters and verses
+// may be separated by either a period or a colon. A bare number is a verse
+// if a chapter has already been given and a chapter otherwise. It returns
+// nil if the reference can't be understood.
+func ParseReference(reference string) []ScriptureRange {
+	groups := bookRe.FindStringSubmatch(strings.TrimSpace(reference))
+	if groups == nil {
+		return nil
+	}
+
+	book := strings.TrimSpace(groups[1])
+	chapter := 0 // The chapter in context, if any
+
+	var ranges []ScriptureRange
+	for _, part := range strings.Split(groups[2], ",") {
+		bounds := strings.Split(strings.TrimSpace(part), "-")
+		if len(bounds) > 2 {
+			return nil
+		}
+
+		r := ScriptureRange{Book: book}
+
+		c, v, ok := parseChapterVerse(bounds[0], chapter)
+		if !ok {
+			return nil
+		}
+		r.StartChapter, r.StartVerse = c, v
+		r.EndChapter, r.EndVerse = c, v
+		if v > 0 {
+			chapter = c
+		}
+
+		if len(bounds) == 2 {
+			// In "4.1-22" the 22 is a verse in chapter 4, but in "4-6" the 6
+			// is a chapter.
+			context := chapter
+			if v == 0 {
+				context = 0
+			}
+
+			c, v, ok := parseChapterVerse(bounds[1], context)
+			if !ok {
+				return nil
+			}
+			r.EndChapter, r.EndVerse = c, v
+			if v > 0 {
+				chapter = c
+			}
+		}
+
+		ranges = append(ranges, r)
+	}
+
+	return ranges
+}
+
+// parseChapterVerse parses "12.3", "12:3" or "3". A bare number is a verse
+// in chapter if chapter is non-zero and a whole chapter otherwise.
+func parseChapterVerse(text string, chapter int) (int, int, bool) {
+	text = strings.TrimSpace(text)
+
+	if i := strings.IndexAny(text, ".:"); i >= 0 {
+		c, e1 := strconv.Atoi(text[:i])
+		v, e2 := strconv.Atoi(text[i+1:])
+		return c, v, e1 == nil && e2 == nil
+	}
+
+	n, e := strconv.Atoi(text)
+	if e != nil {
+		return 0, 0, false
+	}
+
+	if chapter > 0 {
+		return chapter, n, true
+	}
+
+	return n, 0, true
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseReference(t *testing.T) {
+	testCases := []struct {
+		reference string
+		ranges    []ScriptureRange
+	}{
+		{"Matt 22.15-17", []ScriptureRange{{"Matt", 22, 15, 22, 17}}},
+		{"Matt 22.15-23.39", []ScriptureRange{{"Matt", 22, 15, 23, 39}}},
+		{"Matt 1:1-7:8", []ScriptureRange{{"Matt", 1, 1, 7, 8}}},
+		{"1 Cor 1.10-18", []ScriptureRange{{"1 Cor", 1, 10, 1, 18}}},
+		{"Wis 4, 6, 7, 2", []ScriptureRange{
+			{"Wis", 4, 0, 4, 0},
+			{"Wis", 6, 0, 6, 0},
+			{"Wis", 7, 0, 7, 0},
+			{"Wis", 2, 0, 2, 0},
+		}},
+		{"Matt 10.32-33, 37-38, 19.27-30", []ScriptureRange{
+			{"Matt", 10, 32, 10, 33},
+			{"Matt", 10, 37, 10, 38},
+			{"Matt", 19, 27, 19, 30},
+		}},
+		{"Gen 1-2", []ScriptureRange{{"Gen", 1, 0, 2, 0}}},
+		{"Not a reference", nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.reference, func(t *testing.T) {
+			ranges := ParseReference(tc.reference)
+			if !reflect.DeepEqual(ranges, tc.ranges) {
+				t.Errorf("ranges should be %v but are %v", tc.ranges, ranges)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"github.com/gorilla/mux"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+func (self *CalendarServer) tomorrowHandler(writer http.ResponseWriter, request *http.Request) {
+	self.writeRelativeDay(writer, request, 1)
+}
+
+func (self *CalendarServer) yesterdayHandler(writer http.ResponseWriter, request *http.Request) {
+	self.writeRelativeDay(writer, request, -1)
+}
+
+// nextHandler writes the next n days, beginning with today in the requested
+// time zone.
+func (self *CalendarServer) nextHandler(writer http.ResponseWriter, request *http.Request) {
+	// Mux only sends digits, but there may be too many of them to parse.
+	n, e := strconv.Atoi(mux.Vars(request)["n"])
+	if e != nil || n < 1 || n > RangeMaxDays {
+		writeError(writer, request, http.StatusBadRequest, fmt.Sprintf("The number of days must be between 1 and %d.", RangeMaxDays))
+		return
+	}
+
+	tz, e := requestLocation(request)
+	if e != nil {
+		writeError(writer, request, http.StatusBadRequest, e.Error())
+		return
+	}
+
+	start := Today(tz)
+
+	writer.Header().Add("Vary", TimeZoneHeader)
+	self.writeDaysFrom(writer, request, start, start.AddDate(0, 0, n-1))
+}
+
+// thisWeekHandler writes the ISO week, Monday through Sunday, that contains
+// today in the requested time zone.
+func (self *CalendarServer) thisWeekHandler(writer http.ResponseWriter, request *http.Request) {
+	tz, e := requestLocation(request)
+	if e != nil {
+		writeError(writer, request, http.StatusBadRequest, e.Error())
+		return
+	}
+
+	today := Today(tz)
+	monday := today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+
+	writer.Header().Add("Vary", TimeZoneHeader)
+	self.writeDaysFrom(writer, request, monday, monday.AddDate(0, 0, 6))
+}
+
+func (self *CalendarServer) weekHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+
+	// Mux is setup to only send things that match this pattern, so we don't
+	// need to handle the errors.
+	year, _ := strconv.Atoi(vars["year"])
+	week, _ := strconv.Atoi(vars["isoweek"])
+
+	if !validYear(writer, request, year) {
+		return
+	}
+
+	monday, ok := ISOWeekStart(year, week)
+	if !ok {
+		writeError(writer, request, http.StatusBadRequest, fmt.Sprintf("%d does not have a week %d.", year, week))
+		return
+	}
+
+	writer.Header().Set("Cache-Control", CacheControl)
+	self.writeDaysFrom(writer, request, monday, monday.AddDate(0, 0, 6))
+}
+
+// writeDaysFrom writes the days from start through end the same way the
+// month and range endpoints do.
+func (self *CalendarServer) writeDaysFrom(writer http.ResponseWriter, request *http.Request, start, end time.Time) {
+	bible, e := self.requestBible(request, false)
+	if e != nil {
+		writeError(writer, request, http.StatusBadRequest, e.Error())
+		return
+	}
+
+	fields, bible, e := self.requestDayFields(request, "application/json", bible)
+	if e != nil {
+		writeError(writer, request, http.StatusBadRequest, e.Error())
+		return
+	}
+
+	stream := PrefersNDJSON(request.Header.Get("Accept"))
+	writer.Header().Add("Vary", "Accept")
+
+	factory := self.newDayFactory()
+	self.writeDays(writer, request, factory, start, end, bible, stream, fields)
+}
+
+// ISOWeekStart returns the Monday that begins the given ISO 8601 week. Week 1
+// is the week containing January 4th. Ok is false if the year doesn't have
+// that many weeks.
+func ISOWeekStart(year, week int) (monday time.Time, ok bool) {
+	if week < 1 || week > 53 {
+		return monday, false
+	}
+
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC)
+	monday = jan4.AddDate(0, 0, -(int(jan4.Weekday())+6)%7+(week-1)*7)
+
+	if y, w := monday.ISOWeek(); y != year || w != week {
+		return monday, false
+	}
+
+	return monday, true
+}
@@ -0,0 +1,32 @@
+package main
+
+import "testing"
+
+func TestISOWeekStart(t *testing.T) {
+	testCases := []struct {
+		year, week int
+		monday     string
+		ok         bool
+	}{
+		{2025, 1, "2024-12-30", true},
+		{2025, 17, "2025-04-21", true},
+		{2025, 52, "2025-12-22", true},
+		{2025, 53, "", false},
+		{2026, 53, "2026-12-28", true},
+		{2021, 1, "2021-01-04", true},
+		{2020, 53, "2020-12-28", true},
+		{2025, 0, "", false},
+	}
+
+	for _, tc := range testCases {
+		monday, ok := ISOWeekStart(tc.year, tc.week)
+		if ok != tc.ok {
+			t.Errorf("%d-W%02d: ok should be %t but is %t", tc.year, tc.week, tc.ok, ok)
+			continue
+		}
+
+		if ok && monday.Format("2006-01-02") != tc.monday {
+			t.Errorf("%d-W%02d should begin on %s but begins on %s", tc.year, tc.week, tc.monday, monday.Format("2006-01-02"))
+		}
+	}
+}
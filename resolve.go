@@ -0,0 +1,439 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// A dateAnchor returns the civil date of a liturgical anchor, such as Pascha,
+// in a year of a jurisdiction's calendar.
+type dateAnchor func(year int, useJulian bool) time.Time
+
+// dateAnchors are the named days that date expressions can be built from.
+var dateAnchors = map[string]dateAnchor{
+	"pascha":         paschaAnchor(0),
+	"easter":         paschaAnchor(0),
+	"palm sunday":    paschaAnchor(-7),
+	"ascension":      paschaAnchor(39),
+	"pentecost":      paschaAnchor(49),
+	"trinity":        paschaAnchor(49),
+	"trinity sunday": paschaAnchor(49),
+	"nativity":       fixedAnchor(12, 25),
+	"christmas":      fixedAnchor(12, 25),
+	"theophany":      fixedAnchor(1, 6),
+	"epiphany":       fixedAnchor(1, 6),
+}
+
+func paschaAnchor(offset int) dateAnchor {
+	return func(year int, useJulian bool) time.Time {
+		return ComputePascha(year).AddDate(0, 0, offset)
+	}
+}
+
+// fixedAnchor returns the anchor for a fixed feast. On the Julian calendar a
+// late December feast falls in January of the following civil year, so the
+// feast that falls in a civil year may belong to the previous Julian year.
+func fixedAnchor(month, day int) dateAnchor {
+	return func(year int, useJulian bool) time.Time {
+		date := FixedFeastDate(useJulian, year, month, day)
+		if date.Year() > year {
+			date = FixedFeastDate(useJulian, year-1, month, day)
+		}
+
+		return date
+	}
+}
+
+var (
+	weekdayNames = map[string]time.Weekday{
+		"sunday":    time.Sunday,
+		"monday":    time.Monday,
+		"tuesday":   time.Tuesday,
+		"wednesday": time.Wednesday,
+		"thursday":  time.Thursday,
+		"friday":    time.Friday,
+		"saturday":  time.Saturday,
+	}
+
+	monthNames = map[string]time.Month{
+		"january":   time.January,
+		"february":  time.February,
+		"march":     time.March,
+		"april":     time.April,
+		"may":       time.May,
+		"june":      time.June,
+		"july":      time.July,
+		"august":    time.August,
+		"september": time.September,
+		"october":   time.October,
+		"november":  time.November,
+		"december":  time.December,
+	}
+
+	numberWords = map[string]int{
+		"a": 1, "an": 1, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5,
+		"six": 6, "seven": 7, "eight": 8, "nine": 9, "ten": 10, "eleven": 11,
+		"twelve": 12, "thirteen": 13, "fourteen": 14, "fifteen": 15,
+		"sixteen": 16, "seventeen": 17, "eighteen": 18, "nineteen": 19,
+		"twenty": 20, "thirty": 30, "forty": 40, "fifty": 50,
+	}
+
+	// The number of days in each unit
+	dateUnits = map[string]int{
+		"day":   1,
+		"days":  1,
+		"week":  7,
+		"weeks": 7,
+	}
+
+	// Words that don't change the meaning of an expression
+	fillerWords = map[string]bool{
+		"the":   true,
+		"of":    true,
+		"on":    true,
+		"feast": true,
+	}
+)
+
+// SpokenDate resolves the date an Alexa request asks about. The AMAZON.DATE
+// slot arrives as an ISO date or week, such as 2025-W03 for "next week",
+// while a free-text AMAZON.SearchQuery slot carries phrases like "the Sunday
+// after Theophany". Both are interpreted by ResolveDate. Without either,
+// the date is today.
+func SpokenDate(date, phrase string, today time.Time, jurisdiction Jurisdiction) (time.Time, error) {
+	expression := date
+	if len(expression) == 0 {
+		expression = phrase
+	}
+
+	if len(strings.TrimSpace(expression)) == 0 {
+		return today, nil
+	}
+
+	return ResolveDate(expression, today, jurisdiction)
+}
+
+// ResolveDate interprets a date expression such as "next Sunday", "Pascha
+// 2027", "the Sunday after Theophany" or "in three days". Today and the
+// result are dates at midnight UTC. Liturgical anchors are computed on the
+// jurisdiction's calendar.
+func ResolveDate(expression string, today time.Time, jurisdiction Jurisdiction) (time.Time, error) {
+	resolver := dateResolver{today: today, useJulian: jurisdiction.UseJulian}
+
+	if date, ok := resolver.resolve(dateWords(expression)); ok {
+		return date, nil
+	}
+
+	return time.Time{}, fmt.Errorf("'%s' is not a date I understand.", strings.TrimSpace(expression))
+}
+
+// dateWords splits an expression into lower case words, dropping punctuation
+// and filler words. Hyphens are kept so that ISO dates survive.
+func dateWords(expression string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(expression), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-'
+	})
+
+	var words []string
+	for _, field := range fields {
+		if !fillerWords[field] {
+			words = append(words, field)
+		}
+	}
+
+	return words
+}
+
+type dateResolver struct {
+	today     time.Time
+	useJulian bool
+}
+
+func (self dateResolver) resolve(words []string) (time.Time, bool) {
+	if len(words) == 0 {
+		return time.Time{}, false
+	}
+
+	// Relative to another date, as in "the Sunday after Theophany" or "the
+	// day before yesterday". The right side may itself be relative.
+	for i, word := range words {
+		if word != "after" && word != "before" && word != "from" {
+			continue
+		}
+
+		if i == 0 {
+			return time.Time{}, false
+		}
+
+		base, ok := self.resolve(words[i+1:])
+		if !ok {
+			return base, false
+		}
+
+		if word == "before" {
+			return offsetDate(words[:i], base, -1)
+		}
+		return offsetDate(words[:i], base, 1)
+	}
+
+	switch {
+	case words[0] == "in":
+		return offsetDate(words[1:], self.today, 1)
+	case words[len(words)-1] == "ago":
+		return offsetDate(words[:len(words)-1], self.today, -1)
+	}
+
+	if len(words) == 1 {
+		switch words[0] {
+		case "today", "now", "tonight":
+			return self.today, true
+		case "tomorrow":
+			return self.today.AddDate(0, 0, 1), true
+		case "yesterday":
+			return self.today.AddDate(0, 0, -1), true
+		}
+
+		if date, e := time.Parse("2006-01-02", words[0]); e == nil {
+			return date, true
+		}
+
+		if date, ok := parseISOWeek(words[0]); ok {
+			return date, true
+		}
+	}
+
+	// "Sunday", "next Sunday", "last Pascha"
+	modifier := "this"
+	switch words[0] {
+	case "this", "coming", "next", "last", "previous":
+		modifier, words = words[0], words[1:]
+	}
+
+	if len(words) == 1 {
+		if weekday, ok := weekdayNames[words[0]]; ok {
+			return self.weekday(modifier, weekday)
+		}
+	}
+
+	if anchor, ok := dateAnchors[strings.Join(words, " ")]; ok {
+		return self.anchor(modifier, anchor)
+	}
+
+	if modifier != "this" {
+		return time.Time{}, false
+	}
+
+	// "Pascha 2027"
+	if year, ok := parseYear(words[len(words)-1]); ok {
+		if anchor, ok := dateAnchors[strings.Join(words[:len(words)-1], " ")]; ok {
+			return anchor(year, self.useJulian), true
+		}
+	}
+
+	return self.monthDay(words)
+}
+
+// weekday returns the nearest matching weekday. This includes today, while
+// next and last do not.
+func (self dateResolver) weekday(modifier string, weekday time.Weekday) (time.Time, bool) {
+	switch modifier {
+	case "next":
+		return stepToWeekday(self.today, weekday, 1), true
+	case "last", "previous":
+		return stepToWeekday(self.today, weekday, -1), true
+	}
+
+	return self.today.AddDate(0, 0, (int(weekday)-int(self.today.Weekday())+7)%7), true
+}
+
+// anchor returns the nearest occurrence of an anchor. Without a modifier this
+// includes today.
+func (self dateResolver) anchor(modifier string, anchor dateAnchor) (time.Time, bool) {
+	year := self.today.Year()
+
+	switch modifier {
+	case "next":
+		for y := year; y <= year+1; y++ {
+			if date := anchor(y, self.useJulian); date.After(self.today) {
+				return date, true
+			}
+		}
+	case "last", "previous":
+		for y := year; y >= year-1; y-- {
+			if date := anchor(y, self.useJulian); date.Before(self.today) {
+				return date, true
+			}
+		}
+	default:
+		for y := year; y <= year+1; y++ {
+			if date := anchor(y, self.useJulian); !date.Before(self.today) {
+				return date, true
+			}
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// monthDay parses "January 7", "7 January" or either with a year. Without a
+// year the next occurrence, including today, is used.
+func (self dateResolver) monthDay(words []string) (time.Time, bool) {
+	if len(words) < 2 || len(words) > 3 {
+		return time.Time{}, false
+	}
+
+	month, ok := monthNames[words[0]]
+	day, e := strconv.Atoi(strings.TrimRight(words[1], "stndrh"))
+	if !ok {
+		month, ok = monthNames[words[1]]
+		day, e = strconv.Atoi(strings.TrimRight(words[0], "stndrh"))
+	}
+	if !ok || e != nil || day < 1 || day > 31 {
+		return time.Time{}, false
+	}
+
+	year := self.today.Year()
+	if len(words) == 3 {
+		if year, ok = parseYear(words[2]); !ok {
+			return time.Time{}, false
+		}
+	}
+
+	date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	if date.Month() != month {
+		return time.Time{}, false
+	}
+
+	if len(words) == 2 && date.Before(self.today) {
+		date = date.AddDate(1, 0, 0)
+	}
+
+	return date, true
+}
+
+// offsetDate moves from base by the amount in words, which may be a weekday,
+// as in "Sunday after", or a count of days or weeks, as in "three days
+// after". Sign is -1 to move backward.
+func offsetDate(words []string, base time.Time, sign int) (time.Time, bool) {
+	switch len(words) {
+	case 1:
+		if weekday, ok := weekdayNames[words[0]]; ok {
+			return stepToWeekday(base, weekday, sign), true
+		}
+
+		if unit, ok := dateUnits[words[0]]; ok {
+			return base.AddDate(0, 0, sign*unit), true
+		}
+	case 2:
+		n, ok := numberWords[words[0]]
+		if !ok {
+			var e error
+			if n, e = strconv.Atoi(words[0]); e != nil {
+				return time.Time{}, false
+			}
+		}
+
+		if unit, ok := dateUnits[words[1]]; ok {
+			return base.AddDate(0, 0, sign*n*unit), true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// stepToWeekday returns the first matching weekday strictly after base, or
+// strictly before it if sign is -1.
+func stepToWeekday(base time.Time, weekday time.Weekday, sign int) time.Time {
+	date := base.AddDate(0, 0, sign)
+	for date.Weekday() != weekday {
+		date = date.AddDate(0, 0, sign)
+	}
+
+	return date
+}
+
+func parseYear(word string) (int, bool) {
+	if len(word) != 4 {
+		return 0, false
+	}
+
+	year, e := strconv.Atoi(word)
+	return year, e == nil
+}
+
+// parseISOWeek parses weeks like 2025-w17, which is how Alexa describes "this
+// week", returning the Monday.
+func parseISOWeek(word string) (time.Time, bool) {
+	parts := strings.Split(word, "-w")
+	if len(parts) != 2 {
+		return time.Time{}, false
+	}
+
+	year, ok := parseYear(parts[0])
+	week, e := strconv.Atoi(parts[1])
+	if !ok || e != nil {
+		return time.Time{}, false
+	}
+
+	return ISOWeekStart(year, week)
+}
+
+type ResolveResponse struct {
+	Query   string `json:"query"`
+	Date    string `json:"date"`
+	Weekday string `json:"weekday"`
+	URL     string `json:"url"`
+}
+
+func (self *CalendarServer) resolveHandler(writer http.ResponseWriter, request *http.Request) {
+	query := request.URL.Query().Get("q")
+	if len(strings.TrimSpace(query)) == 0 {
+		writeError(writer, request, http.StatusBadRequest, "A q parameter describing a date is required.")
+		return
+	}
+
+	tz, e := requestLocation(request)
+	if e != nil {
+		writeError(writer, request, http.StatusBadRequest, e.Error())
+		return
+	}
+
+	writer.Header().Add("Vary", TimeZoneHeader)
+
+	date, e := ResolveDate(query, Today(tz), self.jurisdiction)
+	if e != nil {
+		writeError(writer, request, http.StatusBadRequest, e.Error())
+		return
+	}
+
+	if !validYear(writer, request, date.Year()) {
+		return
+	}
+
+	response := ResolveResponse{
+		Query:   query,
+		Date:    date.Format("2006-01-02"),
+		Weekday: date.Weekday().String(),
+	}
+
+	u, e := self.routes["day"].URLPath("year", strconv.Itoa(date.Year()), "month", strconv.Itoa(int(date.Month())), "day", strconv.Itoa(date.Day()))
+	if e == nil {
+		response.URL = u.String()
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "\t")
+
+	if e := encoder.Encode(response); e != nil {
+		writeError(writer, request, http.StatusInternalServerError, "Internal Server Error")
+		log.Printf("Could not marshal json for resolveHandler: %#v.", e)
+	}
+}
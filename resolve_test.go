@@ -0,0 +1,113 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResolveDate(t *testing.T) {
+	// A Wednesday
+	today := time.Date(2025, time.October, 15, 0, 0, 0, 0, time.UTC)
+
+	oca := Jurisdiction{Slug: "oca"}
+	rocor := Jurisdiction{Slug: "rocor", UseJulian: true}
+
+	testCases := []struct {
+		expression   string
+		jurisdiction Jurisdiction
+		date         string
+	}{
+		{"today", oca, "2025-10-15"},
+		{"Tomorrow", oca, "2025-10-16"},
+		{"the day after tomorrow", oca, "2025-10-17"},
+		{"2025-12-25", oca, "2025-12-25"},
+		{"2025-W17", oca, "2025-04-21"},
+		{"Wednesday", oca, "2025-10-15"},
+		{"next Wednesday", oca, "2025-10-22"},
+		{"next Sunday", oca, "2025-10-19"},
+		{"last Sunday", oca, "2025-10-12"},
+		{"in three days", oca, "2025-10-18"},
+		{"in 2 weeks", oca, "2025-10-29"},
+		{"a week ago", oca, "2025-10-08"},
+		{"three days from now", oca, "2025-10-18"},
+		{"Pascha", oca, "2026-04-12"},
+		{"last Pascha", oca, "2025-04-20"},
+		{"Pascha 2027", oca, "2027-05-02"},
+		{"Pentecost 2025", oca, "2025-06-08"},
+		{"Palm Sunday 2025", oca, "2025-04-13"},
+		{"Nativity", oca, "2025-12-25"},
+		{"Nativity", rocor, "2026-01-07"},
+		{"Nativity 2025", rocor, "2025-01-07"},
+		{"Theophany 2026", rocor, "2026-01-19"},
+		{"the Sunday after Theophany", oca, "2026-01-11"},
+		{"the Sunday after Theophany", rocor, "2026-01-25"},
+		{"the Saturday before Pascha 2025", oca, "2025-04-19"},
+		{"two weeks after Pascha 2025", oca, "2025-05-04"},
+		{"January 7", oca, "2026-01-07"},
+		{"October 15th", oca, "2025-10-15"},
+		{"7 January 2027", oca, "2027-01-07"},
+		{"February 30", oca, ""},
+		{"next Pascha 2027", oca, ""},
+		{"after Pascha", oca, ""},
+		{"whenever", oca, ""},
+		{"", oca, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expression+"/"+tc.jurisdiction.Slug, func(t *testing.T) {
+			date, e := ResolveDate(tc.expression, today, tc.jurisdiction)
+			if tc.date == "" {
+				if e == nil {
+					t.Errorf("expected an error but got %s", date.Format("2006-01-02"))
+				}
+				return
+			}
+
+			if e != nil {
+				t.Fatalf("%v", e)
+			}
+
+			if got := date.Format("2006-01-02"); got != tc.date {
+				t.Errorf("expected %s but got %s", tc.date, got)
+			}
+		})
+	}
+}
+
+func TestSpokenDate(t *testing.T) {
+	today := time.Date(2025, time.October, 15, 0, 0, 0, 0, time.UTC)
+	oca := Jurisdiction{Slug: "oca"}
+
+	testCases := []struct {
+		date   string
+		phrase string
+		want   string
+	}{
+		{"", "", "2025-10-15"},
+		{"2025-12-25", "", "2025-12-25"},
+		{"2025-W03", "", "2025-01-13"},
+		{"", "the Sunday after Theophany", "2026-01-11"},
+		{"2025-12-25", "Pascha", "2025-12-25"},
+		{"", "whenever", ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.date+"/"+tc.phrase, func(t *testing.T) {
+			date, e := SpokenDate(tc.date, tc.phrase, today, oca)
+			if tc.want == "" {
+				if e == nil {
+					t.Errorf("expected an error but got %s", date.Format("2006-01-02"))
+				}
+				return
+			}
+
+			if e != nil {
+				t.Fatalf("%v", e)
+			}
+
+			if got := date.Format("2006-01-02"); got != tc.want {
+				t.Errorf("expected %s but got %s", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/brianglass/orthocal"
+	"golang.org/x/text/unicode/norm"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// SearchResponse lists every commemoration in a year that matches a query.
+type SearchResponse struct {
+	Query   string         `json:"query"`
+	Year    int            `json:"year"`
+	Results []SearchResult `json:"results"`
+}
+
+type SearchResult struct {
+	Date string `json:"date"`
+	Kind string `json:"kind"` // One of title, feast or saint
+	Text string `json:"text"`
+}
+
+// searchIndex holds the searchable text of every day in a year. It is built
+// the first time a year is searched and kept for the life of the server.
+type searchIndex struct {
+	once    sync.Once
+	entries []searchEntry
+}
+
+type searchEntry struct {
+	SearchResult
+	folded string
+}
+
+func (self *CalendarServer) searchHandler(writer http.ResponseWriter, request *http.Request) {
+	query := request.URL.Query()
+
+	terms := strings.Fields(foldText(query.Get("q")))
+	if len(terms) == 0 {
+		writeError(writer, request, http.StatusBadRequest, "The q parameter is required.")
+		return
+	}
+
+	var year int
+	if value := query.Get("year"); len(value) > 0 {
+		var e error
+		if year, e = strconv.Atoi(value); e != nil {
+			writeError(writer, request, http.StatusBadRequest, "The year parameter must be a number.")
+			return
+		}
+	} else {
+		tz, e := requestLocation(request)
+		if e != nil {
+			writeError(writer, request, http.StatusBadRequest, e.Error())
+			return
+		}
+		year = time.Now().In(tz).Year()
+	}
+
+	if !validYear(writer, request, year) {
+		return
+	}
+
+	response := SearchResponse{
+		Query:   query.Get("q"),
+		Year:    year,
+		Results: []SearchResult{},
+	}
+
+	for _, entry := range self.getSearchIndex(year).entries {
+		if containsAll(entry.folded, terms) {
+			response.Results = append(response.Results, entry.SearchResult)
+		}
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.Header().Set("Cache-Control", CacheControl)
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "\t")
+
+	if e := encoder.Encode(response); e != nil {
+		writeError(writer, request, http.StatusInternalServerError, "Internal Server Error")
+		log.Printf("Could not marshal json for searchHandler: %#v.", e)
+	}
+}
+
+// getSearchIndex returns the index for a year, building it if necessary.
+// Concurrent searches of the same year wait for a single build.
+func (self *CalendarServer) getSearchIndex(year int) *searchIndex {
+	self.searchLock.Lock()
+	index, ok := self.searchIndexes[year]
+	if !ok {
+		index = new(searchIndex)
+		self.searchIndexes[year] = index
+	}
+	self.searchLock.Unlock()
+
+	// The index outlives the request that triggers it, so it mustn't be
+	// cut short when that request's client goes away.
+	index.once.Do(func() {
+		factory := self.newDayFactory()
+		index.entries = buildSearchEntries(context.Background(), factory, year)
+	})
+
+	return index
+}
+
+func buildSearchEntries(ctx context.Context, factory *orthocal.DayFactory, year int) (entries []searchEntry) {
+	add := func(date, kind string, texts []string) {
+		for _, text := range texts {
+			entries = append(entries, searchEntry{
+				SearchResult: SearchResult{Date: date, Kind: kind, Text: text},
+				folded:       foldText(text),
+			})
+		}
+	}
+
+	for date := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC); date.Year() == year; date = date.AddDate(0, 0, 1) {
+		day := factory.NewDayWithContext(ctx, date.Year(), int(date.Month()), date.Day(), nil)
+		when := date.Format("2006-01-02")
+
+		add(when, "title", day.Titles)
+		add(when, "feast", day.Feasts)
+		add(when, "saint", day.Saints)
+	}
+
+	return entries
+}
+
+// foldText lower-cases text and strips its diacritics so that, for
+// instance, "Sérafim" matches "serafim".
+func foldText(text string) string {
+	var builder strings.Builder
+
+	for _, r := range norm.NFD.String(text) {
+		if !unicode.Is(unicode.Mn, r) {
+			builder.WriteRune(unicode.ToLower(r))
+		}
+	}
+
+	return builder.String()
+}
+
+func containsAll(text string, terms []string) bool {
+	for _, term := range terms {
+		if !strings.Contains(text, term) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFoldText(t *testing.T) {
+	testCases := []struct {
+		text   string
+		folded string
+	}{
+		{"St. Seraphim of Sarov", "st. seraphim of sarov"},
+		{"Sérafim", "serafim"},
+		{"ΑΓΊΑ ΣΟΦΊΑ", "αγια σοφια"},
+		{"Ven. Paisios the Athonite", "ven. paisios the athonite"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.text, func(t *testing.T) {
+			if folded := foldText(tc.text); folded != tc.folded {
+				t.Errorf("folded text should be %q but is %q", tc.folded, folded)
+			}
+		})
+	}
+}
+
+func TestSearchHandler(t *testing.T) {
+	router := mux.NewRouter()
+	server := NewCalendarServer(router.PathPrefix("/api/oca/").Subrouter(), openTestCalendar(t), Jurisdiction{Slug: "oca", Title: "OCA", DoJump: true}, nil)
+
+	tests := []struct {
+		name   string
+		query  string
+		status int
+	}{
+		{"match", "?q=NICHOLAS+myra&year=2025", http.StatusOK},
+		{"again", "?q=nicholas&year=2025", http.StatusOK},
+		{"empty", "?q=+&year=2025", http.StatusBadRequest},
+		{"missing", "?year=2025", http.StatusBadRequest},
+		{"bad year", "?q=nicholas&year=next", http.StatusBadRequest},
+		{"out of range", "?q=nicholas&year=1492", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", "/api/oca/search/"+tt.query, nil)
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.status {
+				t.Fatalf("Expected status %d, got %d: %s", tt.status, recorder.Code, recorder.Body.String())
+			}
+
+			if tt.status != http.StatusOK {
+				return
+			}
+
+			var response SearchResponse
+			if e := json.Unmarshal(recorder.Body.Bytes(), &response); e != nil {
+				t.Fatalf("Could not unmarshal response: %v", e)
+			}
+
+			var found bool
+			for _, result := range response.Results {
+				found = found || (result.Date == "2025-12-06" && result.Kind == "saint")
+			}
+			if !found {
+				t.Errorf("Expected St. Nicholas on 2025-12-06, got %v", response.Results)
+			}
+		})
+	}
+
+	// Both searches should have shared one index and the invalid ones
+	// shouldn't have built any.
+	if len(server.searchIndexes) != 1 {
+		t.Errorf("Expected one search index, got %d", len(server.searchIndexes))
+	}
+}
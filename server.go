@@ -1,62 +1,112 @@
 package main
 
 import (
+	"bytes"
 	"database/sql"
 	"encoding/json"
+	"fmt"
 	"github.com/brianglass/orthocal"
 	"github.com/gorilla/mux"
-	"io"
 	"log"
 	"net/http"
 	"strconv"
+	"sync"
 	"time"
 )
 
 const (
-	CalendarMaxDays = 7 * 30
-	CacheControl    = "max-age=14400"
+	CalendarMaxDays     = 7 * 30
+	CacheControl        = "max-age=14400"
+	DefaultRangeMaxDays = 92
+	YearCacheControl    = "public, max-age=604800"
+	NDJSONMediaType     = "application/x-ndjson"
 )
 
 type CalendarServer struct {
-	db        *sql.DB
-	bible     orthocal.Bible
-	useJulian bool
-	doJump    bool
-	title     string
+	db           *sql.DB
+	bible        orthocal.Bible
+	jurisdiction Jurisdiction
+	routes       map[string]*mux.Route
+
+	searchLock    sync.Mutex
+	searchIndexes map[int]*searchIndex
 }
 
-func NewCalendarServer(router *mux.Router, db *sql.DB, useJulian, doJump bool, bible orthocal.Bible, title string) *CalendarServer {
+func NewCalendarServer(router *mux.Router, db *sql.DB, jurisdiction Jurisdiction, bible orthocal.Bible) *CalendarServer {
 	var self CalendarServer
 
 	self.db = db
 	self.bible = bible
-	self.useJulian = useJulian
-	self.doJump = doJump
-	self.title = title
+	self.jurisdiction = jurisdiction
+	self.searchIndexes = make(map[int]*searchIndex)
+	self.routes = make(map[string]*mux.Route)
+
+	router.NotFoundHandler = http.HandlerFunc(notFoundHandler)
+	router.MethodNotAllowedHandler = http.HandlerFunc(methodNotAllowedHandler)
 
 	r := router.Methods("GET", "HEAD").Subrouter()
 
-	r.HandleFunc(`/`, self.todayHandler)
-	r.HandleFunc(`/ical/`, self.icalHandler)
-	r.HandleFunc(`/{year:\d+}/{month:\d+}/`, self.monthHandler)
-	r.HandleFunc(`/{year:\d+}/{month:\d+}/{day:\d+}/`, self.dayHandler)
+	self.routes["today"] = r.HandleFunc(`/`, self.todayHandler)
+	self.routes["ical"] = r.HandleFunc(`/ical/`, self.icalHandler)
+	self.routes["tomorrow"] = r.HandleFunc(`/tomorrow/`, self.tomorrowHandler)
+	self.routes["yesterday"] = r.HandleFunc(`/yesterday/`, self.yesterdayHandler)
+	self.routes["next"] = r.HandleFunc(`/next/{n:\d+}/`, self.nextHandler)
+	self.routes["this_week"] = r.HandleFunc(`/week/`, self.thisWeekHandler)
+	self.routes["week"] = r.HandleFunc(`/week/{year:\d+}/{isoweek:\d+}/`, self.weekHandler)
+	r.HandleFunc(`/range/`, self.rangeHandler)
+	r.HandleFunc(`/search/`, self.searchHandler)
+	r.HandleFunc(`/resolve/`, self.resolveHandler)
+	r.HandleFunc(`/upcoming/`, self.upcomingHandler)
+	r.HandleFunc(`/paschalion/{year:\d+}/`, self.paschalionHandler)
+	r.HandleFunc(`/fasts/{year:\d+}/`, self.fastsHandler)
+	r.HandleFunc(`/{year:\d+}/`, self.yearHandler)
+	self.routes["month"] = r.HandleFunc(`/{year:\d+}/{month:\d+}/`, self.monthHandler)
+	self.routes["day"] = r.HandleFunc(`/{year:\d+}/{month:\d+}/{day:\d+}/`, self.dayHandler)
+
+	p := router.Methods("POST").Subrouter()
+	p.HandleFunc(`/days`, self.batchHandler)
 
 	return &self
 }
 
 func (self *CalendarServer) todayHandler(writer http.ResponseWriter, request *http.Request) {
-	today := time.Now().In(TZ)
-	factory := orthocal.NewDayFactory(self.useJulian, self.doJump, self.db)
-	Day := factory.NewDayWithContext(request.Context(), today.Year(), int(today.Month()), today.Day(), self.bible)
+	self.writeRelativeDay(writer, request, 0)
+}
 
-	writer.Header().Set("Content-Type", "application/json")
-	encoder := json.NewEncoder(writer)
-	encoder.SetIndent("", "\t")
+// writeRelativeDay writes the day offset days from today in the requested
+// time zone.
+func (self *CalendarServer) writeRelativeDay(writer http.ResponseWriter, request *http.Request, offset int) {
+	bible, e := self.requestBible(request, true)
+	if e != nil {
+		writeError(writer, request, http.StatusBadRequest, e.Error())
+		return
+	}
+
+	tz, e := requestLocation(request)
+	if e != nil {
+		writeError(writer, request, http.StatusBadRequest, e.Error())
+		return
+	}
 
-	if e := encoder.Encode(Day); e != nil {
-		http.Error(writer, "Internal Server Error", http.StatusInternalServerError)
-		log.Printf("Could not marshal json for dayHandler: %#v.", e)
+	format, ok := NegotiateDayFormat(request.Header.Get("Accept"))
+	if !ok {
+		writeError(writer, request, http.StatusNotAcceptable, "None of the requested media types are available.")
+		return
 	}
+
+	fields, bible, e := self.requestDayFields(request, format.MediaType, bible)
+	if e != nil {
+		writeError(writer, request, http.StatusBadRequest, e.Error())
+		return
+	}
+
+	date := Today(tz).AddDate(0, 0, offset)
+	factory := self.newDayFactory()
+	Day := factory.NewDayWithContext(request.Context(), date.Year(), int(date.Month()), date.Day(), bible)
+
+	writer.Header().Add("Vary", "Accept")
+	writer.Header().Add("Vary", TimeZoneHeader)
+	self.writeDay(writer, request, Day, format, tz, DataModified, fields)
 }
 
 func (self *CalendarServer) dayHandler(writer http.ResponseWriter, request *http.Request) {
@@ -68,19 +118,84 @@ func (self *CalendarServer) dayHandler(writer http.ResponseWriter, request *http
 	month, _ := strconv.Atoi(vars["month"])
 	day, _ := strconv.Atoi(vars["day"])
 
-	factory := orthocal.NewDayFactory(self.useJulian, self.doJump, self.db)
-	Day := factory.NewDayWithContext(request.Context(), year, month, day, self.bible)
+	if !validDate(writer, request, year, month, day) {
+		return
+	}
 
-	writer.Header().Set("Content-Type", "application/json")
-	writer.Header().Set("Cache-Control", CacheControl)
-	encoder := json.NewEncoder(writer)
-	encoder.SetIndent("", "\t")
+	bible, e := self.requestBible(request, true)
+	if e != nil {
+		writeError(writer, request, http.StatusBadRequest, e.Error())
+		return
+	}
 
-	e := encoder.Encode(Day)
+	tz, e := requestLocation(request)
 	if e != nil {
-		http.Error(writer, "Internal Server Error", http.StatusInternalServerError)
-		log.Printf("Could not marshal json for dayHandler: %#v.", e)
+		writeError(writer, request, http.StatusBadRequest, e.Error())
+		return
+	}
+
+	format, ok := NegotiateDayFormat(request.Header.Get("Accept"))
+	if !ok {
+		writeError(writer, request, http.StatusNotAcceptable, "None of the requested media types are available.")
+		return
+	}
+
+	fields, bible, e := self.requestDayFields(request, format.MediaType, bible)
+	if e != nil {
+		writeError(writer, request, http.StatusBadRequest, e.Error())
+		return
+	}
+
+	writer.Header().Add("Vary", "Accept")
+
+	// Some formats describe the day relative to today in the requested time
+	// zone, so they go stale when the day rolls over there. Like the iCal
+	// feed, they're only as old as the later of today and the data.
+	modified := DataModified
+	parts := []string{self.jurisdiction.Slug, "day", vars["year"], vars["month"], vars["day"], strconv.FormatBool(bible != nil), format.MediaType, fields.String()}
+	if format.Relative {
+		now := time.Now().In(tz)
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, tz)
+		if today.After(modified) {
+			modified = today
+		}
+
+		writer.Header().Add("Vary", TimeZoneHeader)
+		parts = append(parts, today.Format("2006-01-02"))
+	}
+
+	etag := MakeETag(parts...)
+	if checkNotModified(writer, request, etag, modified) {
+		return
+	}
+
+	factory := self.newDayFactory()
+	Day := factory.NewDayWithContext(request.Context(), year, month, day, bible)
+
+	writer.Header().Set("Cache-Control", CacheControl)
+	self.writeDay(writer, request, Day, format, tz, modified, fields)
+}
+
+// writeDay renders a day in the negotiated format. The day is rendered into
+// a buffer first so that a failure can still be reported cleanly.
+func (self *CalendarServer) writeDay(writer http.ResponseWriter, request *http.Request, day *orthocal.Day, format DayFormat, tz *time.Location, stamp time.Time, fields FieldSet) {
+	var buf bytes.Buffer
+
+	options := FormatOptions{
+		Jurisdiction: self.jurisdiction,
+		TZ:           tz,
+		Stamp:        stamp,
+		Fields:       fields,
 	}
+
+	if e := format.Format(&buf, day, options); e != nil {
+		writeError(writer, request, http.StatusInternalServerError, "Internal Server Error")
+		log.Printf("Could not format %s for writeDay: %#v.", format.MediaType, e)
+		return
+	}
+
+	writer.Header().Set("Content-Type", format.ContentType)
+	writer.Write(buf.Bytes())
 }
 
 func (self *CalendarServer) monthHandler(writer http.ResponseWriter, request *http.Request) {
@@ -91,42 +206,232 @@ func (self *CalendarServer) monthHandler(writer http.ResponseWriter, request *ht
 	year, _ := strconv.Atoi(vars["year"])
 	month, _ := strconv.Atoi(vars["month"])
 
-	factory := orthocal.NewDayFactory(self.useJulian, self.doJump, self.db)
+	if !validDate(writer, request, year, month, 1) {
+		return
+	}
+
+	bible, e := self.requestBible(request, false)
+	if e != nil {
+		writeError(writer, request, http.StatusBadRequest, e.Error())
+		return
+	}
+
+	fields, bible, e := self.requestDayFields(request, "application/json", bible)
+	if e != nil {
+		writeError(writer, request, http.StatusBadRequest, e.Error())
+		return
+	}
+
+	stream := PrefersNDJSON(request.Header.Get("Accept"))
+	writer.Header().Add("Vary", "Accept")
+
+	etag := MakeETag(self.jurisdiction.Slug, "month", vars["year"], vars["month"], strconv.FormatBool(bible != nil), strconv.FormatBool(stream), fields.String())
+	if checkNotModified(writer, request, etag, DataModified) {
+		return
+	}
+
+	start := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, -1)
+
+	factory := self.newDayFactory()
 
-	writer.Header().Set("Content-Type", "application/json")
 	writer.Header().Set("Cache-Control", CacheControl)
-	encoder := json.NewEncoder(writer)
+	self.writeDays(writer, request, factory, start, end, bible, stream, fields)
+}
+
+func (self *CalendarServer) rangeHandler(writer http.ResponseWriter, request *http.Request) {
+	query := request.URL.Query()
+
+	start, e := time.Parse("2006-01-02", query.Get("start"))
+	if e != nil {
+		writeError(writer, request, http.StatusBadRequest, "The start date is missing or is not formatted as YYYY-MM-DD.")
+		return
+	}
+
+	end, e := time.Parse("2006-01-02", query.Get("end"))
+	if e != nil {
+		writeError(writer, request, http.StatusBadRequest, "The end date is missing or is not formatted as YYYY-MM-DD.")
+		return
+	}
+
+	if end.Before(start) {
+		writeError(writer, request, http.StatusBadRequest, "The end date must not be before the start date.")
+		return
+	}
+
+	if !validYear(writer, request, start.Year()) || !validYear(writer, request, end.Year()) {
+		return
+	}
+
+	// Both dates are parsed as UTC midnight, so the difference is always a
+	// whole number of days.
+	if days := int(end.Sub(start).Hours()/24) + 1; days > RangeMaxDays {
+		writeError(writer, request, http.StatusBadRequest, fmt.Sprintf("The range may not span more than %d days.", RangeMaxDays))
+		return
+	}
+
+	writer.Header().Set("Cache-Control", CacheControl)
+	self.writeDaysFrom(writer, request, start, end)
+}
+
+// writeDays writes every day from start through end, inclusive. It stops
+// early if the client goes away. Scripture passages are only included if
+// bible is non-nil, and only the selected fields are written.
+//
+// If stream is true, the days are written as newline delimited JSON, each
+// one flushed as soon as it's generated. Otherwise the days are written as a
+// JSON array, which is buffered so that a failure can still be reported with
+// a proper error response.
+func (self *CalendarServer) writeDays(writer http.ResponseWriter, request *http.Request, factory *orthocal.DayFactory, start, end time.Time, bible orthocal.Bible, stream bool, fields FieldSet) {
+	if stream {
+		self.streamDays(writer, request, factory, start, end, bible, fields)
+		return
+	}
+
+	ctx := request.Context()
+
+	days := []DayResponse{}
+	for date := start; !date.After(end); date = date.AddDate(0, 0, 1) {
+		if e := ctx.Err(); e != nil {
+			log.Printf("Request canceled while generating days: %#v.", e)
+			return
+		}
+
+		d := factory.NewDayWithContext(ctx, date.Year(), int(date.Month()), date.Day(), bible)
+		days = append(days, NewDayResponse(d, self.jurisdiction))
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
 	encoder.SetIndent("", "\t")
 
-	io.WriteString(writer, "[")
-	for day := 1; day <= 31; day++ {
-		date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.Local)
+	value, e := fields.Select(days)
+	if e == nil {
+		e = encoder.Encode(value)
+	}
+
+	if e != nil {
+		writeError(writer, request, http.StatusInternalServerError, "Internal Server Error")
+		log.Printf("Could not marshal json for writeDays: %#v.", e)
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.Write(buf.Bytes())
+}
+
+// streamDays writes one day per line. Once the first day has been sent it's
+// too late to change the status, so a failure is reported by ending the
+// stream with an ErrorResponse line.
+func (self *CalendarServer) streamDays(writer http.ResponseWriter, request *http.Request, factory *orthocal.DayFactory, start, end time.Time, bible orthocal.Bible, fields FieldSet) {
+	ctx := request.Context()
+	flusher, _ := writer.(http.Flusher)
 
-		// time.Date automatically wraps, so break out once we hit the next month
-		if date.Month() != time.Month(month) {
-			break
+	writer.Header().Set("Content-Type", NDJSONMediaType)
+
+	encoder := json.NewEncoder(writer)
+	for date := start; !date.After(end); date = date.AddDate(0, 0, 1) {
+		if e := ctx.Err(); e != nil {
+			log.Printf("Request canceled while generating days: %#v.", e)
+			return
 		}
 
-		d := factory.NewDayWithContext(request.Context(), date.Year(), int(date.Month()), date.Day(), nil)
+		d := factory.NewDayWithContext(ctx, date.Year(), int(date.Month()), date.Day(), bible)
 
-		if day > 1 {
-			io.WriteString(writer, ", ")
+		// The encoder marshals the whole day before writing anything, so a
+		// failure never leaves half a line behind.
+		value, e := fields.Select(NewDayResponse(d, self.jurisdiction))
+		if e == nil {
+			e = encoder.Encode(value)
 		}
 
-		e := encoder.Encode(d)
 		if e != nil {
-			http.Error(writer, "Internal Server Error", http.StatusInternalServerError)
-			log.Printf("Could not marshal json for dayHandler: %#v.", e)
+			log.Printf("Could not marshal json for streamDays: %#v.", e)
+			encoder.Encode(ErrorResponse{
+				Code:      http.StatusInternalServerError,
+				Message:   "Internal Server Error",
+				RequestId: requestId(request),
+			})
+			return
+		}
+
+		if flusher != nil {
+			flusher.Flush()
 		}
 	}
-	io.WriteString(writer, "]")
 }
 
 func (self *CalendarServer) icalHandler(writer http.ResponseWriter, request *http.Request) {
-	start := time.Now().In(TZ).AddDate(0, 0, -30)
-	factory := orthocal.NewDayFactory(self.useJulian, self.doJump, self.db)
+	tz, e := requestLocation(request)
+	if e != nil {
+		writeError(writer, request, http.StatusBadRequest, e.Error())
+		return
+	}
+
+	now := time.Now().In(tz)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, tz)
+	start := today.AddDate(0, 0, -30)
+
+	// The feed changes whenever the day rolls over in the client's time
+	// zone, so it's only as old as the later of today and the data.
+	modified := DataModified
+	if today.After(modified) {
+		modified = today
+	}
+
+	writer.Header().Add("Vary", TimeZoneHeader)
+	etag := MakeETag(self.jurisdiction.Slug, "ical", start.Format("2006-01-02"), tz.String())
+	if checkNotModified(writer, request, etag, modified) {
+		return
+	}
+
+	factory := self.newDayFactory()
 
 	writer.Header().Set("Content-Type", "text/calendar")
 	writer.Header().Set("Cache-Control", CacheControl)
-	GenerateCalendar(request.Context(), writer, start, CalendarMaxDays, factory, self.title)
+	GenerateCalendar(request.Context(), writer, start, CalendarMaxDays, factory, self.jurisdiction, tz, modified)
+}
+
+// requestDayFields returns the fields the client selected and the bible to
+// use now that the selection is known. Only the JSON format can leave fields
+// out, and there's no need to look up passages nobody asked for.
+func (self *CalendarServer) requestDayFields(request *http.Request, mediaType string, bible orthocal.Bible) (FieldSet, orthocal.Bible, error) {
+	fields, e := requestFields(request)
+	if e != nil {
+		return nil, nil, e
+	}
+
+	if mediaType != "application/json" {
+		return nil, bible, nil
+	}
+
+	if !fields.Includes("readings", "passage") {
+		bible = nil
+	}
+
+	return fields, bible, nil
+}
+
+// newDayFactory returns a factory for the server's jurisdiction.
+func (self *CalendarServer) newDayFactory() *orthocal.DayFactory {
+	return orthocal.NewDayFactory(self.jurisdiction.UseJulian, self.jurisdiction.DoJump, self.db)
+}
+
+// requestBible returns the bible to use for looking up the scripture passages
+// of a request, or nil if the client doesn't want them. Passage lookups are
+// expensive, so clients can opt in or out with the passages query parameter;
+// include is the default when the parameter is absent.
+func (self *CalendarServer) requestBible(request *http.Request, include bool) (orthocal.Bible, error) {
+	if value := request.URL.Query().Get("passages"); len(value) > 0 {
+		var e error
+		if include, e = strconv.ParseBool(value); e != nil {
+			return nil, fmt.Errorf("The passages parameter must be true or false.")
+		}
+	}
+
+	if !include {
+		return nil, nil
+	}
+
+	return self.bible, nil
 }
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	TimeZoneHeader = "X-Timezone"
+)
+
+// Loading a location reads and parses the zoneinfo database, so we keep the
+// ones we've already seen around. Only valid zones are cached, which keeps
+// the cache bounded by the size of the IANA database.
+var locations sync.Map
+
+// LoadLocation is like time.LoadLocation, except that it caches the result
+// and only accepts IANA zone names.
+func LoadLocation(name string) (*time.Location, error) {
+	if location, ok := locations.Load(name); ok {
+		return location.(*time.Location), nil
+	}
+
+	// time.LoadLocation treats these specially rather than as IANA names.
+	if len(name) == 0 || name == "Local" {
+		return nil, fmt.Errorf("'%s' is not a valid time zone.", name)
+	}
+
+	location, e := time.LoadLocation(name)
+	if e != nil {
+		return nil, fmt.Errorf("'%s' is not a valid time zone.", name)
+	}
+
+	locations.Store(name, location)
+
+	return location, nil
+}
+
+// requestLocation returns the time zone a client asked for, either with the
+// tz query parameter or the X-Timezone header. If the client didn't ask for
+// one, TZ is returned.
+func requestLocation(request *http.Request) (*time.Location, error) {
+	name := request.URL.Query().Get("tz")
+	if len(name) == 0 {
+		name = request.Header.Get(TimeZoneHeader)
+	}
+
+	if len(name) == 0 {
+		return TZ, nil
+	}
+
+	return LoadLocation(name)
+}
+
+// Today returns the current date in tz as midnight UTC, which is how dates
+// are represented throughout the service.
+func Today(tz *time.Location) time.Time {
+	now := time.Now().In(tz)
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultUpcomingLimit = 5
+	UpcomingMaxLimit     = 30
+
+	UpcomingFeast = "feast"
+	UpcomingFast  = "fast"
+)
+
+type upcomingEvent struct {
+	Name   string
+	Kind   string
+	Anchor dateAnchor
+}
+
+// upcomingEvents are the great feasts and the beginnings of the fasting
+// seasons, in the order they occur within a church year.
+var upcomingEvents = []upcomingEvent{
+	{"Nativity of the Theotokos", UpcomingFeast, fixedAnchor(9, 8)},
+	{"Elevation of the Cross", UpcomingFeast, fixedAnchor(9, 14)},
+	{"Nativity Fast", UpcomingFast, fixedAnchor(11, 15)},
+	{"Entry of the Theotokos", UpcomingFeast, fixedAnchor(11, 21)},
+	{"Nativity of Christ", UpcomingFeast, fixedAnchor(12, 25)},
+	{"Theophany", UpcomingFeast, fixedAnchor(1, 6)},
+	{"Meeting of the Lord", UpcomingFeast, fixedAnchor(2, 2)},
+	{"Great Lent", UpcomingFast, paschaAnchor(-48)},
+	{"Annunciation", UpcomingFeast, fixedAnchor(3, 25)},
+	{"Palm Sunday", UpcomingFeast, paschaAnchor(-7)},
+	{"Pascha", UpcomingFeast, paschaAnchor(0)},
+	{"Ascension", UpcomingFeast, paschaAnchor(39)},
+	{"Pentecost", UpcomingFeast, paschaAnchor(49)},
+	{"Apostles' Fast", UpcomingFast, apostlesFastAnchor},
+	{"Dormition Fast", UpcomingFast, fixedAnchor(8, 1)},
+	{"Transfiguration", UpcomingFeast, fixedAnchor(8, 6)},
+	{"Dormition of the Theotokos", UpcomingFeast, fixedAnchor(8, 15)},
+}
+
+// apostlesFastAnchor returns the zero time in years without an Apostles'
+// Fast.
+func apostlesFastAnchor(year int, useJulian bool) time.Time {
+	p := NewPaschalion(year, useJulian)
+	if len(p.ApostlesFastBegins) == 0 {
+		return time.Time{}
+	}
+
+	begins, _ := time.Parse("2006-01-02", p.ApostlesFastBegins)
+	return begins
+}
+
+type UpcomingResponse struct {
+	From   string          `json:"from"`
+	Events []UpcomingEvent `json:"events"`
+}
+
+type UpcomingEvent struct {
+	Name          string `json:"name"`
+	Kind          string `json:"kind"`
+	Date          string `json:"date"`
+	DaysRemaining int    `json:"days_remaining"`
+}
+
+// Upcoming returns the next limit great feasts and fast starts on or after
+// from.
+func Upcoming(from time.Time, limit int, useJulian bool) []UpcomingEvent {
+	type occurrence struct {
+		date  time.Time
+		event upcomingEvent
+	}
+
+	// Every event occurs once a year, so the next two years are more than
+	// enough for UpcomingMaxLimit events.
+	var occurrences []occurrence
+	for year := from.Year(); year <= from.Year()+2; year++ {
+		for _, event := range upcomingEvents {
+			date := event.Anchor(year, useJulian)
+			if !date.IsZero() && !date.Before(from) {
+				occurrences = append(occurrences, occurrence{date, event})
+			}
+		}
+	}
+
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return occurrences[i].date.Before(occurrences[j].date)
+	})
+
+	events := make([]UpcomingEvent, 0, limit)
+	for _, o := range occurrences {
+		if len(events) == limit {
+			break
+		}
+
+		events = append(events, UpcomingEvent{
+			Name:          o.event.Name,
+			Kind:          o.event.Kind,
+			Date:          o.date.Format("2006-01-02"),
+			DaysRemaining: int(o.date.Sub(from).Hours() / 24),
+		})
+	}
+
+	return events
+}
+
+func (self *CalendarServer) upcomingHandler(writer http.ResponseWriter, request *http.Request) {
+	query := request.URL.Query()
+
+	limit := DefaultUpcomingLimit
+	if value := query.Get("limit"); len(value) > 0 {
+		var e error
+		if limit, e = strconv.Atoi(value); e != nil || limit < 1 || limit > UpcomingMaxLimit {
+			writeError(writer, request, http.StatusBadRequest, fmt.Sprintf("The limit must be between 1 and %d.", UpcomingMaxLimit))
+			return
+		}
+	}
+
+	var from time.Time
+	if value := query.Get("from"); len(value) > 0 {
+		var e error
+		if from, e = time.Parse("2006-01-02", value); e != nil {
+			writeError(writer, request, http.StatusBadRequest, "The from date is not formatted as YYYY-MM-DD.")
+			return
+		}
+
+		writer.Header().Set("Cache-Control", CacheControl)
+	} else {
+		tz, e := requestLocation(request)
+		if e != nil {
+			writeError(writer, request, http.StatusBadRequest, e.Error())
+			return
+		}
+
+		from = Today(tz)
+		writer.Header().Add("Vary", TimeZoneHeader)
+	}
+
+	if !validYear(writer, request, from.Year()) {
+		return
+	}
+
+	response := UpcomingResponse{
+		From:   from.Format("2006-01-02"),
+		Events: Upcoming(from, limit, self.jurisdiction.UseJulian),
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "\t")
+
+	if e := encoder.Encode(response); e != nil {
+		writeError(writer, request, http.StatusInternalServerError, "Internal Server Error")
+		log.Printf("Could not marshal json for upcomingHandler: %#v.", e)
+	}
+}
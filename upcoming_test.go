@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestUpcoming(t *testing.T) {
+	testCases := []struct {
+		from      string
+		useJulian bool
+		expected  []UpcomingEvent
+	}{
+		{"2025-10-15", false, []UpcomingEvent{
+			{"Nativity Fast", UpcomingFast, "2025-11-15", 31},
+			{"Entry of the Theotokos", UpcomingFeast, "2025-11-21", 37},
+			{"Nativity of Christ", UpcomingFeast, "2025-12-25", 71},
+		}},
+		{"2025-10-15", true, []UpcomingEvent{
+			{"Nativity Fast", UpcomingFast, "2025-11-28", 44},
+			{"Entry of the Theotokos", UpcomingFeast, "2025-12-04", 50},
+			{"Nativity of Christ", UpcomingFeast, "2026-01-07", 84},
+		}},
+		// There is no Apostles' Fast in 2024 on the Revised Julian calendar.
+		{"2024-05-05", false, []UpcomingEvent{
+			{"Pascha", UpcomingFeast, "2024-05-05", 0},
+			{"Ascension", UpcomingFeast, "2024-06-13", 39},
+			{"Pentecost", UpcomingFeast, "2024-06-23", 49},
+			{"Dormition Fast", UpcomingFast, "2024-08-01", 88},
+		}},
+		{"2024-05-05", true, []UpcomingEvent{
+			{"Pascha", UpcomingFeast, "2024-05-05", 0},
+			{"Ascension", UpcomingFeast, "2024-06-13", 39},
+			{"Pentecost", UpcomingFeast, "2024-06-23", 49},
+			{"Apostles' Fast", UpcomingFast, "2024-07-01", 57},
+		}},
+	}
+
+	for _, tc := range testCases {
+		from, _ := time.Parse("2006-01-02", tc.from)
+		events := Upcoming(from, len(tc.expected), tc.useJulian)
+
+		if len(events) != len(tc.expected) {
+			t.Fatalf("%s: expected %d events but got %d", tc.from, len(tc.expected), len(events))
+		}
+
+		for i, event := range events {
+			if event != tc.expected[i] {
+				t.Errorf("%s (julian %t): expected %+v but got %+v", tc.from, tc.useJulian, tc.expected[i], event)
+			}
+		}
+	}
+}
+
+func TestUpcomingHandler(t *testing.T) {
+	router, _ := newTestRouter(t, nil, nil)
+
+	testCases := []struct {
+		query  string
+		status int
+	}{
+		{"?from=2025-10-15&limit=3", http.StatusOK},
+		{"?from=2025-10-15&limit=0", http.StatusBadRequest},
+		{fmt.Sprintf("?from=2025-10-15&limit=%d", UpcomingMaxLimit+1), http.StatusBadRequest},
+		{"?from=2025-10-15&limit=some", http.StatusBadRequest},
+		{"?from=10/15/2025", http.StatusBadRequest},
+		{fmt.Sprintf("?from=%d-10-15", FirstYear-1), http.StatusNotFound},
+		{fmt.Sprintf("?from=%d-10-15", LastYear+1), http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			request := httptest.NewRequest("GET", "/api/oca/upcoming/"+tc.query, nil)
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, request)
+
+			if tc.status != http.StatusOK {
+				checkErrorResponse(t, recorder, tc.status)
+				return
+			}
+
+			if recorder.Code != http.StatusOK {
+				t.Fatalf("Expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+			}
+
+			var response UpcomingResponse
+			if e := json.Unmarshal(recorder.Body.Bytes(), &response); e != nil {
+				t.Fatalf("Could not unmarshal response: %v", e)
+			}
+
+			if response.From != "2025-10-15" || len(response.Events) != 3 {
+				t.Errorf("Expected 3 events from 2025-10-15, got %+v", response)
+			}
+		})
+	}
+}
+
+func TestUpcomingLimit(t *testing.T) {
+	from := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	events := Upcoming(from, UpcomingMaxLimit, true)
+	if len(events) != UpcomingMaxLimit {
+		t.Errorf("expected %d events but got %d", UpcomingMaxLimit, len(events))
+	}
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/brianglass/orthocal"
+	"github.com/gorilla/mux"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The v2 API describes days with response types owned by this service rather
+// than whatever encoding/json produces from orthocal.Day, so changes to
+// orthocal can't silently change the schema. The golden tests in v2_test.go
+// pin the schema; changing them is a breaking change for clients.
+
+type V2Day struct {
+	Date           string           `json:"date"`
+	OldStyleDate   string           `json:"old_style_date,omitempty"`
+	Weekday        string           `json:"weekday"`
+	Jurisdiction   string           `json:"jurisdiction"`
+	Titles         []string         `json:"titles"`
+	Commemorations V2Commemorations `json:"commemorations"`
+	Fasting        V2Fasting        `json:"fasting"`
+	Readings       []V2Reading      `json:"readings"`
+}
+
+type V2Commemorations struct {
+	Feasts []string `json:"feasts"`
+	Saints []string `json:"saints"`
+}
+
+type V2Fasting struct {
+	Level       int    `json:"level"`
+	Description string `json:"description"`
+	Exception   string `json:"exception"`
+}
+
+type V2Reading struct {
+	Source      string           `json:"source"`
+	Book        string           `json:"book"`
+	Description string           `json:"description"`
+	Display     string           `json:"display"`
+	References  []ScriptureRange `json:"references"`
+	Passage     []V2Verse        `json:"passage"`
+}
+
+type V2Verse struct {
+	Chapter   int    `json:"chapter"`
+	Verse     int    `json:"verse"`
+	Text      string `json:"text"`
+	Paragraph bool   `json:"paragraph_start"`
+}
+
+// NewV2Day converts an orthocal.Day. Slices are never nil so that clients
+// always see arrays rather than nulls. The old style date is only present
+// for jurisdictions on the Julian calendar.
+func NewV2Day(day *orthocal.Day, jurisdiction Jurisdiction) V2Day {
+	date := dayDate(day)
+
+	v := V2Day{
+		Date:         date.Format("2006-01-02"),
+		Weekday:      date.Weekday().String(),
+		Jurisdiction: jurisdiction.Slug,
+		Titles:       nonNil(day.Titles),
+		Commemorations: V2Commemorations{
+			Feasts: nonNil(day.Feasts),
+			Saints: nonNil(day.Saints),
+		},
+		Fasting: V2Fasting{
+			Level:       day.FastLevel,
+			Description: day.FastLevelDesc,
+			Exception:   day.FastExceptionDesc,
+		},
+		Readings: make([]V2Reading, 0, len(day.Readings)),
+	}
+
+	if jurisdiction.UseJulian {
+		v.OldStyleDate = formatOldStyleDate(date)
+	}
+
+	for _, reading := range day.Readings {
+		r := V2Reading{
+			Source:      reading.Source,
+			Book:        reading.Book,
+			Description: reading.Description,
+			Display:     reading.Display,
+			References:  ParseReference(reading.Display),
+			Passage:     make([]V2Verse, 0, len(reading.Passage)),
+		}
+
+		if r.References == nil {
+			r.References = []ScriptureRange{}
+		}
+
+		for _, verse := range reading.Passage {
+			r.Passage = append(r.Passage, V2Verse{
+				Chapter:   verse.Chapter,
+				Verse:     verse.Verse,
+				Text:      strings.TrimSpace(markupRe.ReplaceAllString(verse.Content, "")),
+				Paragraph: verse.Paragraph,
+			})
+		}
+
+		v.Readings = append(v.Readings, r)
+	}
+
+	return v
+}
+
+func nonNil(items []string) []string {
+	if items == nil {
+		return []string{}
+	}
+
+	return items
+}
+
+// V2CalendarServer serves the v2 API for the jurisdiction of a
+// CalendarServer.
+type V2CalendarServer struct {
+	calendar *CalendarServer
+}
+
+func NewV2CalendarServer(router *mux.Router, calendar *CalendarServer) *V2CalendarServer {
+	var self V2CalendarServer
+
+	self.calendar = calendar
+
+	router.NotFoundHandler = http.HandlerFunc(notFoundHandler)
+	router.MethodNotAllowedHandler = http.HandlerFunc(methodNotAllowedHandler)
+
+	r := router.Methods("GET", "HEAD").Subrouter()
+
+	r.HandleFunc(`/`, self.todayHandler)
+	r.HandleFunc(`/{year:\d+}/{month:\d+}/`, self.monthHandler)
+	r.HandleFunc(`/{year:\d+}/{month:\d+}/{day:\d+}/`, self.dayHandler)
+
+	return &self
+}
+
+func (self *V2CalendarServer) todayHandler(writer http.ResponseWriter, request *http.Request) {
+	bible, e := self.calendar.requestBible(request, true)
+	if e != nil {
+		writeError(writer, request, http.StatusBadRequest, e.Error())
+		return
+	}
+
+	tz, e := requestLocation(request)
+	if e != nil {
+		writeError(writer, request, http.StatusBadRequest, e.Error())
+		return
+	}
+
+	today := time.Now().In(tz)
+	factory := self.calendar.newDayFactory()
+	day := factory.NewDayWithContext(request.Context(), today.Year(), int(today.Month()), today.Day(), bible)
+
+	writer.Header().Add("Vary", TimeZoneHeader)
+	self.writeJSON(writer, request, NewV2Day(day, self.calendar.jurisdiction))
+}
+
+func (self *V2CalendarServer) dayHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+
+	// Mux is setup to only send things that match this pattern, so we don't
+	// need to handle the errors.
+	year, _ := strconv.Atoi(vars["year"])
+	month, _ := strconv.Atoi(vars["month"])
+	day, _ := strconv.Atoi(vars["day"])
+
+	if !validDate(writer, request, year, month, day) {
+		return
+	}
+
+	bible, e := self.calendar.requestBible(request, true)
+	if e != nil {
+		writeError(writer, request, http.StatusBadRequest, e.Error())
+		return
+	}
+
+	etag := MakeETag("v2", self.calendar.jurisdiction.Slug, "day", vars["year"], vars["month"], vars["day"], strconv.FormatBool(bible != nil))
+	if checkNotModified(writer, request, etag, DataModified) {
+		return
+	}
+
+	factory := self.calendar.newDayFactory()
+	d := factory.NewDayWithContext(request.Context(), year, month, day, bible)
+
+	writer.Header().Set("Cache-Control", CacheControl)
+	self.writeJSON(writer, request, NewV2Day(d, self.calendar.jurisdiction))
+}
+
+func (self *V2CalendarServer) monthHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	ctx := request.Context()
+
+	// Mux is setup to only send things that match this pattern, so we don't
+	// need to handle the errors.
+	year, _ := strconv.Atoi(vars["year"])
+	month, _ := strconv.Atoi(vars["month"])
+
+	if !validDate(writer, request, year, month, 1) {
+		return
+	}
+
+	bible, e := self.calendar.requestBible(request, false)
+	if e != nil {
+		writeError(writer, request, http.StatusBadRequest, e.Error())
+		return
+	}
+
+	etag := MakeETag("v2", self.calendar.jurisdiction.Slug, "month", vars["year"], vars["month"], strconv.FormatBool(bible != nil))
+	if checkNotModified(writer, request, etag, DataModified) {
+		return
+	}
+
+	factory := self.calendar.newDayFactory()
+
+	days := []V2Day{}
+	start := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	for date := start; date.Month() == start.Month(); date = date.AddDate(0, 0, 1) {
+		if e := ctx.Err(); e != nil {
+			log.Printf("Request canceled while generating v2 month: %#v.", e)
+			return
+		}
+
+		d := factory.NewDayWithContext(ctx, date.Year(), int(date.Month()), date.Day(), bible)
+		days = append(days, NewV2Day(d, self.calendar.jurisdiction))
+	}
+
+	writer.Header().Set("Cache-Control", CacheControl)
+	self.writeJSON(writer, request, days)
+}
+
+// writeJSON encodes into a buffer first so that a failure can still be
+// reported with a proper error response.
+func (self *V2CalendarServer) writeJSON(writer http.ResponseWriter, request *http.Request, value interface{}) {
+	var buf bytes.Buffer
+
+	encoder := json.NewEncoder(&buf)
+	encoder.SetIndent("", "\t")
+
+	if e := encoder.Encode(value); e != nil {
+		writeError(writer, request, http.StatusInternalServerError, "Internal Server Error")
+		log.Printf("Could not marshal json for writeJSON: %#v.", e)
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.Write(buf.Bytes())
+}
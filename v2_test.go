@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"github.com/brianglass/orthocal"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+// These tests pin the v2 schema. If one fails, either fix the code or, if
+// the schema change is intentional and clients have been told, rerun with
+// -update.
+func TestV2DayGolden(t *testing.T) {
+	oca := Jurisdiction{Slug: "oca", Title: "OCA", DoJump: true}
+	rocor := Jurisdiction{Slug: "rocor", Title: "ROCOR", UseJulian: true, DoJump: true}
+
+	testCases := []struct {
+		name         string
+		jurisdiction Jurisdiction
+		day          *orthocal.Day
+	}{
+		{"v2_day_full", oca, &orthocal.Day{
+			Year:              2024,
+			Month:             1,
+			Day:               7,
+			Titles:            []string{"Sunday after the Nativity"},
+			Feasts:            []string{"Synaxis of the Most Holy Theotokos"},
+			Saints:            []string{"Ven. Euthymius the Great", "St. Joseph the Betrothed"},
+			FastLevel:         0,
+			FastLevelDesc:     "Fast-free",
+			FastExceptionDesc: "",
+			Readings: []orthocal.Reading{
+				{
+					Source:      "Sunday after Nativity",
+					Book:        "Apostol",
+					Description: "",
+					Display:     "Gal 1.11-19",
+					Passage: orthocal.Passage{
+						{Book: "GAL", Chapter: 1, Verse: 11, Content: "But I certify you, brethren,", Paragraph: true},
+						{Book: "GAL", Chapter: 1, Verse: 12, Content: "For I neither received it of man,"},
+					},
+				},
+				{
+					Source:  "Sunday after Nativity",
+					Book:    "Matthew",
+					Display: "Matt 2.13-23",
+				},
+			},
+		}},
+		{"v2_day_empty", oca, &orthocal.Day{
+			Year:          2019,
+			Month:         2,
+			Day:           11,
+			FastLevelDesc: "No Fast",
+		}},
+		{"v2_day_julian", rocor, &orthocal.Day{
+			Year:          2025,
+			Month:         1,
+			Day:           7,
+			Titles:        []string{"The Nativity of Christ"},
+			Feasts:        []string{"Nativity of our Lord, God, and Savior Jesus Christ"},
+			FastLevelDesc: "Fast-free",
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			encoder := json.NewEncoder(&buf)
+			encoder.SetIndent("", "\t")
+			if e := encoder.Encode(NewV2Day(tc.day, tc.jurisdiction)); e != nil {
+				t.Fatalf("Could not marshal json: %v", e)
+			}
+
+			golden := filepath.Join("testdata", tc.name+".golden.json")
+			if *update {
+				if e := os.WriteFile(golden, buf.Bytes(), 0644); e != nil {
+					t.Fatalf("Could not update %s: %v", golden, e)
+				}
+			}
+
+			expected, e := os.ReadFile(golden)
+			if e != nil {
+				t.Fatalf("Could not read %s: %v", golden, e)
+			}
+
+			if !bytes.Equal(buf.Bytes(), expected) {
+				t.Errorf("%s does not match.\nExpected:\n%s\nGot:\n%s", golden, expected, buf.Bytes())
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/brianglass/orthocal"
+	"github.com/gorilla/mux"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// YearSummary is a compact description of an entire year, suitable for
+// building printed calendars.
+type YearSummary struct {
+	Year int          `json:"year"`
+	Days []DaySummary `json:"days"`
+}
+
+// DaySummary is the subset of an orthocal.Day that is useful for an overview
+// of the year. It never includes scripture text. The old style date is only
+// present for jurisdictions on the Julian calendar.
+type DaySummary struct {
+	Date              string           `json:"date"`
+	OldStyleDate      string           `json:"old_style_date,omitempty"`
+	Titles            []string         `json:"titles"`
+	Feasts            []string         `json:"feasts"`
+	FastLevel         int              `json:"fast_level"`
+	FastLevelDesc     string           `json:"fast_level_desc"`
+	FastExceptionDesc string           `json:"fast_exception_desc"`
+	Readings          []ReadingSummary `json:"readings"`
+}
+
+type ReadingSummary struct {
+	Source      string `json:"source"`
+	Description string `json:"description"`
+	Display     string `json:"display"`
+}
+
+func NewDaySummary(day *orthocal.Day, jurisdiction Jurisdiction) DaySummary {
+	date := time.Date(day.Year, time.Month(day.Month), day.Day, 0, 0, 0, 0, time.UTC)
+
+	summary := DaySummary{
+		Date:              date.Format("2006-01-02"),
+		Titles:            day.Titles,
+		Feasts:            day.Feasts,
+		FastLevel:         day.FastLevel,
+		FastLevelDesc:     day.FastLevelDesc,
+		FastExceptionDesc: day.FastExceptionDesc,
+		Readings:          make([]ReadingSummary, 0, len(day.Readings)),
+	}
+
+	if jurisdiction.UseJulian {
+		summary.OldStyleDate = formatOldStyleDate(date)
+	}
+
+	for _, r := range day.Readings {
+		summary.Readings = append(summary.Readings, ReadingSummary{
+			Source:      r.Source,
+			Description: r.Description,
+			Display:     r.Display,
+		})
+	}
+
+	return summary
+}
+
+func (self *CalendarServer) yearHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	ctx := request.Context()
+
+	// Mux is setup to only send things that match this pattern, so we don't
+	// need to handle the errors.
+	year, _ := strconv.Atoi(vars["year"])
+
+	if !validYear(writer, request, year) {
+		return
+	}
+
+	factory := self.newDayFactory()
+
+	summary := YearSummary{Year: year}
+	for date := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC); date.Year() == year; date = date.AddDate(0, 0, 1) {
+		if e := ctx.Err(); e != nil {
+			log.Printf("Request canceled while generating year summary: %#v.", e)
+			return
+		}
+
+		day := factory.NewDayWithContext(ctx, date.Year(), int(date.Month()), date.Day(), nil)
+		summary.Days = append(summary.Days, NewDaySummary(day, self.jurisdiction))
+	}
+
+	// Encode into a buffer so that a failure can still be reported cleanly.
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetIndent("", "\t")
+
+	if e := encoder.Encode(summary); e != nil {
+		writeError(writer, request, http.StatusInternalServerError, "Internal Server Error")
+		log.Printf("Could not marshal json for yearHandler: %#v.", e)
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.Header().Set("Cache-Control", YearCacheControl)
+	writer.Write(buf.Bytes())
+}
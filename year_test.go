@@ -0,0 +1,18 @@
+package main
+
+import (
+	"github.com/brianglass/orthocal"
+	"testing"
+)
+
+func TestDaySummaryOldStyleDate(t *testing.T) {
+	day := &orthocal.Day{Year: 2025, Month: 1, Day: 7}
+
+	if s := NewDaySummary(day, Jurisdiction{UseJulian: true}); s.OldStyleDate != "2024-12-25" {
+		t.Errorf("unexpected old style date %q", s.OldStyleDate)
+	}
+
+	if s := NewDaySummary(day, Jurisdiction{}); s.OldStyleDate != "" {
+		t.Errorf("expected no old style date but got %q", s.OldStyleDate)
+	}
+}